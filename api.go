@@ -13,6 +13,8 @@ var _ ApiHandler = (*Api)(nil)
 type ApiHandler interface {
 	VaultHandler
 	GetProtectedApi(authKey *ecdsa.PrivateKey, vaultId string) ProtectedApiHandler
+	GetProtectedApiWithHttpClient(authKey *ecdsa.PrivateKey, vaultId string, httpClient *http.Client) ProtectedApiHandler
+	GetProtectedApiWithSigner(signer SignerDecrypter, vaultId string) ProtectedApiHandler
 	GetNewIdentityKeyPair() (*ecdsa.PrivateKey, *ecdsa.PublicKey, error)
 }
 
@@ -20,27 +22,53 @@ type Api struct {
 	endpoint   string
 	client     graphql.Client
 	httpClient *http.Client
+	options    options
 }
 
-func NewApi(endpoint string, httpClient *http.Client) ApiHandler {
-	return &Api{
+func NewApi(endpoint string, httpClient *http.Client, opts ...Option) ApiHandler {
+	a := &Api{
 		endpoint:   endpoint,
 		httpClient: httpClient,
-		client:     graphql.NewClient(endpoint, httpClient),
 	}
+	for _, opt := range opts {
+		opt(&a.options)
+	}
+	a.client = a.options.graphqlClient(endpoint, a.options.httpClient(httpClient))
+	return a
 }
 
+// GetProtectedApi returns an api authenticated by authKey. All requests go through the http.Client given to NewApi.
 func (a *Api) GetProtectedApi(authKey *ecdsa.PrivateKey, vaultId string) ProtectedApiHandler {
-	h := http.Client{
-		Transport: &authedTransport{wrapped: http.DefaultTransport, key: authKey, vaultId: vaultId},
-	}
+	return a.GetProtectedApiWithHttpClient(authKey, vaultId, a.httpClient)
+}
+
+// GetProtectedApiWithHttpClient returns an api authenticated by authKey which sends all requests through httpClient
+// instead of the http.Client given to NewApi.
+func (a *Api) GetProtectedApiWithHttpClient(authKey *ecdsa.PrivateKey, vaultId string, httpClient *http.Client) ProtectedApiHandler {
+	return a.newProtectedApi(NewPrivateKeySigner(authKey), vaultId, httpClient)
+}
+
+// GetProtectedApiWithSigner returns an api authenticated by signer, which also decrypts all values.
+func (a *Api) GetProtectedApiWithSigner(signer SignerDecrypter, vaultId string) ProtectedApiHandler {
+	return a.newProtectedApi(signer, vaultId, a.httpClient)
+}
+
+func (a *Api) newProtectedApi(signer SignerDecrypter, vaultId string, httpClient *http.Client) *ProtectedApi {
+	h := authedClient(a.options.httpClient(httpClient), signer, vaultId, a.options.tokenRefreshSkew())
 
 	return &ProtectedApi{
-		vaultId:  vaultId,
-		authKey:  authKey,
-		api:      a,
-		endpoint: a.endpoint,
-		client:   graphql.NewClient(a.endpoint, &h),
+		vaultId:         vaultId,
+		signer:          signer,
+		api:             a,
+		autoShare:       a.options.autoShare,
+		valueHistory:    a.options.valueHistory,
+		endpoint:        a.endpoint,
+		client:          a.options.graphqlClient(a.endpoint, h),
+		trustAnchors:    a.options.trustAnchors(vaultId),
+		cache:           a.options.valueCache(),
+		watchInterval:   a.options.watchInterval(),
+		watchTransport:  a.options.watcher,
+		binaryChunkSize: a.options.binaryChunkSize(),
 	}
 }
 
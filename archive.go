@@ -0,0 +1,329 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+// ValueArchiveVersion is the archive format written by ExportValues
+const ValueArchiveVersion = 1
+
+// exportPageSize is the number of values loaded per request by ExportValues
+const exportPageSize = DefaultValuePageSize
+
+// ValueArchive holds exported values, each secret is encrypted for the public key Recipient.
+// Use Encode and DecodeValueArchive to store it as JSON.
+type ValueArchive struct {
+	Version   int                    `json:"version"`
+	VaultId   string                 `json:"vaultId"`
+	Pattern   string                 `json:"pattern"`
+	CreatedAt time.Time              `json:"createdAt"`
+	Recipient helper.Base64PublicPem `json:"recipient"`
+	Values    []*ArchivedValue       `json:"values"`
+}
+
+// ArchivedValue is a single value of a ValueArchive
+type ArchivedValue struct {
+	Name      string     `json:"name"`
+	Type      ValueType  `json:"type"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+	// Secret is the value encrypted for the recipient of the archive
+	Secret string `json:"secret"`
+}
+
+// Encode writes the archive as JSON to w
+func (v *ValueArchive) Encode(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// DecodeValueArchive reads an archive written by Encode. It returns an error wrapping ErrArchiveVersion
+// if the archive was written in an unknown format.
+func DecodeValueArchive(r io.Reader) (*ValueArchive, error) {
+	archive := new(ValueArchive)
+	if err := json.NewDecoder(r).Decode(archive); err != nil {
+		return nil, err
+	}
+	if archive.Version != ValueArchiveVersion {
+		return nil, fmt.Errorf("%w: %d", ErrArchiveVersion, archive.Version)
+	}
+	return archive, nil
+}
+
+// ImportConflictPolicy decides what ImportValues does with a value whose name already exists
+type ImportConflictPolicy string
+
+const (
+	// ImportSkip keeps the existing value, default
+	ImportSkip ImportConflictPolicy = "skip"
+	// ImportOverwrite replaces the secret and type of the existing value
+	ImportOverwrite ImportConflictPolicy = "overwrite"
+	// ImportRename adds the value with the first free name ending in -1, -2, ...
+	ImportRename ImportConflictPolicy = "rename"
+)
+
+// ImportAction is what ImportValues did, or would do on a dry run, with an archived value
+type ImportAction string
+
+const (
+	ImportCreated     ImportAction = "created"
+	ImportOverwritten ImportAction = "overwritten"
+	ImportRenamed     ImportAction = "renamed"
+	ImportSkipped     ImportAction = "skipped"
+)
+
+// ImportOptions configures ImportValues
+type ImportOptions struct {
+	Conflict ImportConflictPolicy
+	// DryRun only decrypts the archive and reports the actions without changing the vault
+	DryRun bool
+	// Decrypter decrypts the secrets of the archive, default is the signer of the api
+	// which must then be the recipient of the archive.
+	Decrypter Decrypter
+}
+
+// ImportResult reports the action taken for a single archived value
+type ImportResult struct {
+	Name string
+	// TargetName is the name of the value in the vault, it differs from Name if the value was renamed
+	TargetName string
+	Action     ImportAction
+	// ValueId is the id of the created, overwritten or skipped value, empty on a dry run for new values
+	ValueId string
+}
+
+func (a *ProtectedApi) ExportValues(pattern string, recipient *ecdsa.PublicKey) (*ValueArchive, error) {
+	return a.ExportValuesContext(context.Background(), pattern, recipient)
+}
+
+// ExportValuesContext exports all values matching pattern, f.e.: VALUES.a.>, into an archive with every secret
+// encrypted for recipient. Names, types and timestamps are preserved, prior versions are not exported.
+// The chunks of binary values are read into their archived value and split again by ImportValues.
+// Only values whose name starts with the pattern up to its first wildcard are loaded, page by page.
+// It fails with an IdentityValueMissingError if a matching value is not shared with the calling identity.
+func (a *ProtectedApi) ExportValuesContext(ctx context.Context, pattern string, recipient *ecdsa.PublicKey) (*ValueArchive, error) {
+	if err := checkValueNamePattern(pattern); err != nil {
+		return nil, err
+	}
+	recipientPem, err := helper.NewBase64PublicPem(recipient)
+	if err != nil {
+		return nil, err
+	}
+	pemKey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	identityId, err := pemKey.GetIdentityId(a.vaultId)
+	if err != nil {
+		return nil, err
+	}
+	archive := &ValueArchive{
+		Version:   ValueArchiveVersion,
+		VaultId:   a.vaultId,
+		Pattern:   pattern,
+		CreatedAt: time.Now(),
+		Recipient: recipientPem,
+		Values:    make([]*ArchivedValue, 0),
+	}
+	byName := valueNamePrefixFilter(pattern)
+	for offset := 0; ; {
+		resp, err := exportValues(ctx, a.client, byName, exportPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		if resp.QueryValue == nil {
+			return archive, nil
+		}
+		for _, v := range resp.QueryValue.Data {
+			if !matchValueName(pattern, v.Name) || isCompanionValue(v.Name) {
+				continue
+			}
+			value, err := a.exportValue(ctx, identityId, recipientPem, v)
+			if err != nil {
+				return nil, err
+			}
+			archive.Values = append(archive.Values, value)
+		}
+		offset += len(resp.QueryValue.Data)
+		if len(resp.QueryValue.Data) < exportPageSize || offset >= resp.QueryValue.TotalCount {
+			return archive, nil
+		}
+	}
+}
+
+// exportValue decrypts v and encrypts its secret for recipient
+func (a *ProtectedApi) exportValue(ctx context.Context, identityId string, recipient helper.Base64PublicPem, v *exportValuesQueryValueValueQueryResultDataValue) (*ArchivedValue, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	values := make([]EncryptenValue, 0, len(v.Value))
+	for _, identityValue := range v.Value {
+		values = append(values, identityValue)
+	}
+	secret, err := a.getDecryptedPassframe(identityId, values)
+	if err == nil {
+		secret, err = a.inlineBinaryValue(ctx, v.Name, v.Type, secret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("export value %s: %w", v.Name, err)
+	}
+	encrypted, err := recipient.Encrypt(secret)
+	if err != nil {
+		return nil, fmt.Errorf("export value %s: %w", v.Name, err)
+	}
+	return &ArchivedValue{
+		Name:      v.Name,
+		Type:      v.Type,
+		CreatedAt: v.CreatedAt,
+		UpdatedAt: v.UpdatedAt,
+		Secret:    encrypted,
+	}, nil
+}
+
+func (a *ProtectedApi) ImportValues(archive *ValueArchive, opts ImportOptions) ([]*ImportResult, error) {
+	return a.ImportValuesContext(context.Background(), archive, opts)
+}
+
+// ImportValuesContext adds all values of archive to the vault, resolving existing names as set by opts.Conflict.
+// All secrets are decrypted before the first value is written, so a damaged archive changes nothing.
+// Values are shared with every identity allowed to read them like AddValue does, the server sets new timestamps.
+// Binary values are split into chunks again as configured by WithBinaryChunkSize.
+// On error the results of the values imported so far are returned.
+func (a *ProtectedApi) ImportValuesContext(ctx context.Context, archive *ValueArchive, opts ImportOptions) ([]*ImportResult, error) {
+	if archive.Version != ValueArchiveVersion {
+		return nil, fmt.Errorf("%w: %d", ErrArchiveVersion, archive.Version)
+	}
+	conflict := opts.Conflict
+	if conflict == "" {
+		conflict = ImportSkip
+	}
+	decrypter := opts.Decrypter
+	if decrypter == nil {
+		own, err := helper.NewBase64PublicPem(a.signer.PublicKey())
+		if err != nil {
+			return nil, err
+		}
+		if own != archive.Recipient {
+			return nil, ErrArchiveRecipient
+		}
+		decrypter = a.signer
+	}
+
+	secrets := make([]string, 0, len(archive.Values))
+	// binaries holds the data of binary values, they are stored with AddBinaryValue
+	binaries := make(map[int][]byte)
+	for i, v := range archive.Values {
+		if strings.Contains(v.Name, "*") || strings.Contains(v.Name, ">") {
+			return nil, fmt.Errorf("import value %s: %w", v.Name, ErrInvalidValueKey)
+		}
+		if isCompanionValue(v.Name) {
+			return nil, fmt.Errorf("import value %s: versions and chunks can not be imported", v.Name)
+		}
+		secret, err := decrypter.Decrypt(v.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("import value %s: %w", v.Name, err)
+		}
+		secrets = append(secrets, string(secret))
+		data, ok, err := inlinedBinaryData(v.Name, v.Type, string(secret))
+		if err != nil {
+			return nil, fmt.Errorf("import value %s: %w", v.Name, err)
+		}
+		if ok {
+			binaries[i] = data
+		}
+	}
+
+	// taken holds the names used by this import, a dry run does not create them
+	taken := make(map[string]bool)
+	results := make([]*ImportResult, 0, len(archive.Values))
+	for i, v := range archive.Values {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+		result := &ImportResult{Name: v.Name, TargetName: v.Name, Action: ImportCreated}
+		existing, err := a.existingValueId(ctx, v.Name)
+		if err != nil {
+			return results, err
+		}
+		if existing != "" || taken[v.Name] {
+			switch conflict {
+			case ImportSkip:
+				result.Action = ImportSkipped
+				result.ValueId = existing
+			case ImportOverwrite:
+				result.Action = ImportOverwritten
+				result.ValueId = existing
+			case ImportRename:
+				result.Action = ImportRenamed
+				result.TargetName, err = a.freeValueName(ctx, v.Name, taken)
+				if err != nil {
+					return results, err
+				}
+			default:
+				return results, fmt.Errorf("unknown import conflict policy %s", conflict)
+			}
+		}
+		taken[result.TargetName] = true
+		if !opts.DryRun {
+			data, binary := binaries[i]
+			switch result.Action {
+			case ImportCreated, ImportRenamed:
+				if binary {
+					result.ValueId, err = a.AddBinaryValueContext(ctx, result.TargetName, data)
+				} else {
+					result.ValueId, err = a.AddValueContext(ctx, result.TargetName, secrets[i], v.Type)
+				}
+			case ImportOverwritten:
+				if binary {
+					_, err = a.updateBinaryValue(ctx, result.ValueId, result.TargetName, data)
+				} else {
+					_, err = a.UpdateValueContext(ctx, result.ValueId, result.TargetName, secrets[i], v.Type)
+				}
+			}
+			if err != nil {
+				return results, fmt.Errorf("import value %s: %w", v.Name, err)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// existingValueId returns the id of the value with name, empty if there is none
+func (a *ProtectedApi) existingValueId(ctx context.Context, name string) (string, error) {
+	value, err := a.GetValueByNameContext(ctx, name)
+	if errors.Is(err, ErrValueNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return value.Id, nil
+}
+
+// freeValueName returns the first name of the form name-1, name-2, ... which is neither taken nor exists
+func (a *ProtectedApi) freeValueName(ctx context.Context, name string, taken map[string]bool) (string, error) {
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", name, i)
+		if taken[candidate] {
+			continue
+		}
+		existing, err := a.existingValueId(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == "" {
+			return candidate, nil
+		}
+	}
+}
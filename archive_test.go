@@ -0,0 +1,125 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cryptvault-cloud/api"
+	"github.com/cryptvault-cloud/helper"
+)
+
+func TestExportImportValues(t *testing.T) {
+	_, source, _ := newTestVault(t)
+	a, target, vaultId := newTestVault(t)
+	ctx := context.Background()
+
+	for name, value := range map[string]string{"VALUES.a.x": "x", "VALUES.a.y": "y", "VALUES.b.z": "z"} {
+		if _, err := source.AddValue(name, value, api.ValueTypeString); err != nil {
+			t.Fatalf("AddValue() error = %v", err)
+		}
+	}
+	recipient, err := target.CreateIdentity("migration", rightInputs(t, "(rw)VALUES.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	if _, err := target.AddValue("VALUES.a.x", "existing", api.ValueTypeString); err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	archive, err := source.ExportValuesContext(ctx, "VALUES.a.>", &recipient.PrivateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("ExportValues() error = %v", err)
+	}
+	if len(archive.Values) != 2 {
+		t.Fatalf("ExportValues() exported %d values, want 2", len(archive.Values))
+	}
+	for _, v := range archive.Values {
+		if v.Type != api.ValueTypeString || v.CreatedAt == nil || v.UpdatedAt == nil || v.Secret == "" || v.Secret == "x" || v.Secret == "y" {
+			t.Errorf("archived value = %+v, want type, timestamps and encrypted secret", v)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := archive.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	archive, err = api.DecodeValueArchive(&buf)
+	if err != nil {
+		t.Fatalf("DecodeValueArchive() error = %v", err)
+	}
+
+	if _, err := target.ImportValuesContext(ctx, archive, api.ImportOptions{}); !errors.Is(err, api.ErrArchiveRecipient) {
+		t.Errorf("ImportValues() by other identity error = %v, want ErrArchiveRecipient", err)
+	}
+	importer := a.GetProtectedApi(recipient.PrivateKey, vaultId)
+
+	results, err := importer.ImportValuesContext(ctx, archive, api.ImportOptions{Conflict: api.ImportRename, DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportValues() dry run error = %v", err)
+	}
+	actions := make(map[string]api.ImportAction)
+	for _, v := range results {
+		actions[v.TargetName] = v.Action
+	}
+	if actions["VALUES.a.x-1"] != api.ImportRenamed || actions["VALUES.a.y"] != api.ImportCreated {
+		t.Errorf("ImportValues() dry run = %v, want VALUES.a.x-1 renamed and VALUES.a.y created", actions)
+	}
+	if _, err := target.GetValueByName("VALUES.a.y"); !errors.Is(err, api.ErrValueNotFound) {
+		t.Errorf("dry run created VALUES.a.y, error = %v", err)
+	}
+
+	if _, err := importer.ImportValuesContext(ctx, archive, api.ImportOptions{Conflict: api.ImportOverwrite}); err != nil {
+		t.Fatalf("ImportValues() error = %v", err)
+	}
+	for name, want := range map[string]string{"VALUES.a.x": "x", "VALUES.a.y": "y"} {
+		value, err := target.GetIdentityValueByName(name)
+		if err != nil {
+			t.Fatalf("GetIdentityValueByName(%s) error = %v", name, err)
+		}
+		if value.Value != want {
+			t.Errorf("GetIdentityValueByName(%s) = %s, want %s", name, value.Value, want)
+		}
+	}
+
+	results, err = importer.ImportValuesContext(ctx, archive, api.ImportOptions{})
+	if err != nil {
+		t.Fatalf("ImportValues() error = %v", err)
+	}
+	for _, v := range results {
+		if v.Action != api.ImportSkipped || v.ValueId == "" {
+			t.Errorf("ImportValues() result = %+v, want skipped with value id", v)
+		}
+	}
+
+	archive.Version = 2
+	if _, err := importer.ImportValuesContext(ctx, archive, api.ImportOptions{}); !errors.Is(err, api.ErrArchiveVersion) {
+		t.Errorf("ImportValues() of version 2 error = %v, want ErrArchiveVersion", err)
+	}
+}
+
+func TestExportValuesPages(t *testing.T) {
+	_, operator, _ := newTestVault(t)
+	ctx := context.Background()
+
+	for i := 0; i <= api.DefaultValuePageSize; i++ {
+		if _, err := operator.AddValue(fmt.Sprintf("VALUES.a.v%d", i), "secret", api.ValueTypeString); err != nil {
+			t.Fatalf("AddValue() error = %v", err)
+		}
+	}
+	if _, err := operator.AddValue("VALUES.b.z", "other", api.ValueTypeString); err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	recipient, _, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	archive, err := operator.ExportValuesContext(ctx, "VALUES.a.>", &recipient.PublicKey)
+	if err != nil {
+		t.Fatalf("ExportValues() error = %v", err)
+	}
+	if len(archive.Values) != api.DefaultValuePageSize+1 {
+		t.Errorf("ExportValues() exported %d values, want %d", len(archive.Values), api.DefaultValuePageSize+1)
+	}
+}
@@ -1,24 +1,103 @@
 package api
 
 import (
-	"crypto/ecdsa"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/cryptvault-cloud/helper"
 )
 
+// DefaultTokenRefreshSkew is the time before expiry at which a cached JWT is signed again
+const DefaultTokenRefreshSkew = 30 * time.Second
+
+// authedTransport signs a JWT with the signer and reuses it until it approaches its expiry.
+// If the server answers with 401 Unauthorized the token is refreshed and the request is sent once again.
 type authedTransport struct {
 	wrapped http.RoundTripper
-	key     *ecdsa.PrivateKey
+	signer  Signer
 	vaultId string
+	skew    time.Duration
+	now     func() time.Time
+
+	mu      sync.Mutex
+	jwt     string
+	expires time.Time
 }
 
 func (t *authedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	jwt, err := helper.SignJWT(t.key, t.vaultId)
+	jwt, err := t.token(false)
 	if err != nil {
 		return nil, err
 	}
+	resp, err := t.wrapped.RoundTrip(withAuthorization(req, jwt))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+
+	// server rejected the token, maybe it expired earlier as expected, so force a new one
+	jwt, err = t.token(true)
+	if err != nil {
+		return resp, nil
+	}
+	retry := withAuthorization(req, jwt)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	resp.Body.Close()
+	return t.wrapped.RoundTrip(retry)
+}
+
+// token returns the cached JWT or signs a new one if it expires within skew or refresh is set
+func (t *authedTransport) token(refresh bool) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock()
+	if !refresh && t.jwt != "" && now.Add(t.skew).Before(t.expires) {
+		return t.jwt, nil
+	}
+	jwt, err := t.signer.SignJWT(t.vaultId)
+	if err != nil {
+		return "", err
+	}
+	message, _, err := helper.DecodeJWT(jwt)
+	if err != nil {
+		return "", err
+	}
+	t.jwt = jwt
+	t.expires = message.Expired
+	return jwt, nil
+}
+
+func (t *authedTransport) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+func withAuthorization(req *http.Request, jwt string) *http.Request {
+	req = req.Clone(req.Context())
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
-	return t.wrapped.RoundTrip(req)
+	return req
+}
+
+// authedClient returns a copy of httpClient whose transport signs every request.
+// Timeout, cookie jar and redirect policy of httpClient are kept.
+func authedClient(httpClient *http.Client, signer Signer, vaultId string, skew time.Duration) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	wrapped := httpClient.Transport
+	if wrapped == nil {
+		wrapped = http.DefaultTransport
+	}
+	h := *httpClient
+	h.Transport = &authedTransport{wrapped: wrapped, signer: signer, vaultId: vaultId, skew: skew}
+	return &h
 }
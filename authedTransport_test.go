@@ -0,0 +1,97 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+func TestAuthedClientKeepsCallerTransport(t *testing.T) {
+	key, _, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var authorization string
+	base := &http.Client{
+		Timeout: 3 * time.Second,
+		Transport: RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			authorization = req.Header.Get("Authorization")
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+		}),
+	}
+
+	client := authedClient(base, NewPrivateKeySigner(key), "vault", DefaultTokenRefreshSkew)
+	if client.Timeout != base.Timeout {
+		t.Errorf("authedClient() Timeout = %v, want %v", client.Timeout, base.Timeout)
+	}
+	resp, err := client.Get("http://cryptvault.test/query")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		t.Errorf("request through caller transport has Authorization %q, want bearer token", authorization)
+	}
+	if base.Transport == client.Transport {
+		t.Error("authedClient() changed the transport of the given client")
+	}
+}
+
+func TestAuthedTransportCachesToken(t *testing.T) {
+	key, _, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tokens []string
+	rejectNext := false
+	now := time.Now()
+	transport := &authedTransport{
+		signer:  NewPrivateKeySigner(key),
+		vaultId: "vault",
+		skew:    time.Minute,
+		now:     func() time.Time { return now },
+		wrapped: RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			tokens = append(tokens, req.Header.Get("Authorization"))
+			status := http.StatusOK
+			if rejectNext {
+				rejectNext = false
+				status = http.StatusUnauthorized
+			}
+			return &http.Response{StatusCode: status, Body: http.NoBody, Request: req}, nil
+		}),
+	}
+	client := &http.Client{Transport: transport}
+	get := func() int {
+		t.Helper()
+		resp, err := client.Get("http://cryptvault.test/query")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	get()
+	get()
+	if len(tokens) != 2 || tokens[0] != tokens[1] {
+		t.Fatalf("token was not reused: %v", tokens)
+	}
+
+	now = now.Add(5 * time.Minute)
+	get()
+	if tokens[2] == tokens[1] {
+		t.Error("token was not refreshed before expiry")
+	}
+
+	now = time.Now()
+	rejectNext = true
+	if status := get(); status != http.StatusOK {
+		t.Errorf("request after rejected token got status %d, want %d", status, http.StatusOK)
+	}
+	if len(tokens) != 5 || tokens[3] != tokens[2] || tokens[4] == tokens[3] {
+		t.Errorf("rejected token was not refreshed: %v", tokens)
+	}
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultBinaryChunkSize is the size in bytes above which binary values are split if WithBinaryChunkSize is not set
+const DefaultBinaryChunkSize = 512 << 10
+
+// valueChunkSegment separates the name of a binary value from its chunks
+const valueChunkSegment = "._chunk."
+
+// ValueKind is the kind of content a JSON value holds, see ValueKindOf
+type ValueKind string
+
+const (
+	ValueKindBinary      ValueKind = "binary"
+	ValueKindCertificate ValueKind = "certificate"
+	ValueKindKeyPair     ValueKind = "keypair"
+)
+
+// ValueKindOf returns the kind of the values written by AddBinaryValue, AddCertificateValue and
+// AddKeyPairValue, empty for all other values.
+func ValueKindOf(value *IdentityValue) ValueKind {
+	if value.Type != ValueTypeJson {
+		return ""
+	}
+	var envelope struct {
+		Kind ValueKind `json:"kind"`
+	}
+	if err := json.Unmarshal([]byte(value.Value), &envelope); err != nil {
+		return ""
+	}
+	switch envelope.Kind {
+	case ValueKindBinary, ValueKindCertificate, ValueKindKeyPair:
+		return envelope.Kind
+	}
+	return ""
+}
+
+// isCompanionValue reports whether name belongs to a value stored next to another one,
+// a prior version or a chunk of a binary value
+func isCompanionValue(name string) bool {
+	return strings.Contains(name, valueVersionSegment) || strings.Contains(name, valueChunkSegment)
+}
+
+// addEnvelope stores envelope as JSON value with name
+func (a *ProtectedApi) addEnvelope(ctx context.Context, name string, envelope any) (string, error) {
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return "", err
+	}
+	return a.AddValueContext(ctx, name, string(data), ValueTypeJson)
+}
+
+// getEnvelope decrypts the value with name into envelope. It fails with ErrValueTypeMismatch if the value is not of kind.
+func (a *ProtectedApi) getEnvelope(ctx context.Context, name string, kind ValueKind, envelope any) error {
+	value, err := a.GetIdentityValueByNameContext(ctx, name)
+	if err != nil {
+		return err
+	}
+	if got := ValueKindOf(value); got != kind {
+		return fmt.Errorf("%w: value %s is no %s value", ErrValueTypeMismatch, name, kind)
+	}
+	return json.Unmarshal([]byte(value.Value), envelope)
+}
+
+type binaryEnvelope struct {
+	Kind   ValueKind `json:"kind"`
+	Size   int       `json:"size"`
+	SHA256 string    `json:"sha256"`
+	// Data holds the content if it was not split
+	Data []byte `json:"data,omitempty"`
+	// Chunks is the number of chunks stored below ChunkSet
+	Chunks   int    `json:"chunks,omitempty"`
+	ChunkSet string `json:"chunkSet,omitempty"`
+}
+
+func valueChunkName(name, set string, index int) string {
+	return name + valueChunkSegment + set + "." + strconv.Itoa(index)
+}
+
+func (a *ProtectedApi) AddBinaryValue(name string, data []byte) (string, error) {
+	return a.AddBinaryValueContext(context.Background(), name, data)
+}
+
+// AddBinaryValueContext adds data as value with name. Data larger than WithBinaryChunkSize is split into chunks
+// stored as companion values below the name, f.e.: VALUES.a.keystore._chunk.<set>.0, so identities need
+// a right for VALUES.a.keystore.> to read it. Chunked values have to be deleted with DeleteBinaryValue.
+func (a *ProtectedApi) AddBinaryValueContext(ctx context.Context, name string, data []byte) (string, error) {
+	envelope, err := a.storeBinary(ctx, name, data)
+	if err != nil {
+		return "", err
+	}
+	id, err := a.addEnvelope(ctx, name, envelope)
+	if err != nil {
+		return "", a.deleteChunks(ctx, name, envelope, err)
+	}
+	return id, nil
+}
+
+// updateBinaryValue replaces the value id by data stored as binary value with name.
+// The chunks of the replaced value are deleted once the new envelope was written.
+func (a *ProtectedApi) updateBinaryValue(ctx context.Context, id, name string, data []byte) (string, error) {
+	current, err := a.GetIdentityValueByIdContext(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	envelope, err := a.storeBinary(ctx, name, data)
+	if err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(envelope)
+	if err != nil {
+		return "", a.deleteChunks(ctx, name, envelope, err)
+	}
+	valueId, err := a.UpdateValueContext(ctx, id, name, string(encoded), ValueTypeJson)
+	if err != nil {
+		return "", a.deleteChunks(ctx, name, envelope, err)
+	}
+	previous := new(binaryEnvelope)
+	if ValueKindOf(current) == ValueKindBinary && json.Unmarshal([]byte(current.Value), previous) == nil && previous.Chunks > 0 {
+		if _, err := deleteValueVersions(ctx, a.client, previous.chunkPrefix(current.Name)); err != nil {
+			return valueId, err
+		}
+	}
+	return valueId, nil
+}
+
+// storeBinary returns the envelope of data, data larger than the chunk size is written as chunks below name first
+func (a *ProtectedApi) storeBinary(ctx context.Context, name string, data []byte) (*binaryEnvelope, error) {
+	sum := sha256.Sum256(data)
+	envelope := &binaryEnvelope{Kind: ValueKindBinary, Size: len(data), SHA256: hex.EncodeToString(sum[:])}
+	if len(data) <= a.binaryChunkSize {
+		envelope.Data = data
+		return envelope, nil
+	}
+
+	set := make([]byte, 8)
+	if _, err := rand.Read(set); err != nil {
+		return nil, err
+	}
+	envelope.ChunkSet = hex.EncodeToString(set)
+	for offset := 0; offset < len(data); offset += a.binaryChunkSize {
+		end := min(offset+a.binaryChunkSize, len(data))
+		chunk := base64.StdEncoding.EncodeToString(data[offset:end])
+		if _, err := a.AddValueContext(ctx, valueChunkName(name, envelope.ChunkSet, envelope.Chunks), chunk, ValueTypeString); err != nil {
+			return nil, a.deleteChunks(ctx, name, envelope, err)
+		}
+		envelope.Chunks++
+	}
+	return envelope, nil
+}
+
+// chunkPrefix returns the common prefix of the chunks of the binary value name
+func (e *binaryEnvelope) chunkPrefix(name string) string {
+	return name + valueChunkSegment + e.ChunkSet + "."
+}
+
+// verify checks data against size and checksum of the envelope
+func (e *binaryEnvelope) verify(name string, data []byte) error {
+	sum := sha256.Sum256(data)
+	if len(data) != e.Size || hex.EncodeToString(sum[:]) != e.SHA256 {
+		return fmt.Errorf("%w: checksum of %s does not match", ErrBinaryCorrupted, name)
+	}
+	return nil
+}
+
+// deleteChunks removes the chunks of envelope below name after writing the binary value failed with err
+func (a *ProtectedApi) deleteChunks(ctx context.Context, name string, envelope *binaryEnvelope, err error) error {
+	if envelope.ChunkSet == "" {
+		return err
+	}
+	// the rollback must not be skipped only because the caller context was cancelled
+	if _, err2 := deleteValueVersions(context.WithoutCancel(ctx), a.client, envelope.chunkPrefix(name)); err2 != nil {
+		return errors.Join(errors.New("failed to rollback chunks"), err2, err)
+	}
+	return err
+}
+
+func (a *ProtectedApi) GetBinaryValue(name string) ([]byte, error) {
+	return a.GetBinaryValueContext(context.Background(), name)
+}
+
+// GetBinaryValueContext returns the data of the binary value with name. It fails with ErrBinaryCorrupted
+// if chunks are missing or the data does not match the stored checksum.
+func (a *ProtectedApi) GetBinaryValueContext(ctx context.Context, name string) ([]byte, error) {
+	envelope := new(binaryEnvelope)
+	if err := a.getEnvelope(ctx, name, ValueKindBinary, envelope); err != nil {
+		return nil, err
+	}
+	data := envelope.Data
+	if envelope.Chunks > 0 {
+		chunks, err := a.identityValuesByPattern(ctx, envelope.chunkPrefix(name)+"*", true)
+		if err != nil {
+			return nil, err
+		}
+		data = make([]byte, 0, envelope.Size)
+		for i := 0; i < envelope.Chunks; i++ {
+			chunk, ok := chunks[valueChunkName(name, envelope.ChunkSet, i)]
+			if !ok {
+				return nil, fmt.Errorf("%w: chunk %d of %s is missing", ErrBinaryCorrupted, i, name)
+			}
+			decoded, err := base64.StdEncoding.DecodeString(chunk.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: chunk %d of %s: %w", ErrBinaryCorrupted, i, name, err)
+			}
+			data = append(data, decoded...)
+		}
+	}
+	if err := envelope.verify(name, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// inlineBinaryValue returns secret, the decrypted value name, with the chunks of a binary value read into the envelope
+func (a *ProtectedApi) inlineBinaryValue(ctx context.Context, name string, valueType ValueType, secret string) (string, error) {
+	if ValueKindOf(&IdentityValue{Name: name, Type: valueType, Value: secret}) != ValueKindBinary {
+		return secret, nil
+	}
+	envelope := new(binaryEnvelope)
+	if err := json.Unmarshal([]byte(secret), envelope); err != nil || envelope.Chunks == 0 {
+		return secret, err
+	}
+	data, err := a.GetBinaryValueContext(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	envelope.Data, envelope.Chunks, envelope.ChunkSet = data, 0, ""
+	encoded, err := json.Marshal(envelope)
+	return string(encoded), err
+}
+
+// inlinedBinaryData returns the data of secret if it is a binary envelope written by inlineBinaryValue
+func inlinedBinaryData(name string, valueType ValueType, secret string) ([]byte, bool, error) {
+	if ValueKindOf(&IdentityValue{Name: name, Type: valueType, Value: secret}) != ValueKindBinary {
+		return nil, false, nil
+	}
+	envelope := new(binaryEnvelope)
+	if err := json.Unmarshal([]byte(secret), envelope); err != nil {
+		return nil, true, fmt.Errorf("%w: %w", ErrBinaryCorrupted, err)
+	}
+	if envelope.Chunks > 0 {
+		return nil, true, fmt.Errorf("%w: chunks of %s are missing", ErrBinaryCorrupted, name)
+	}
+	return envelope.Data, true, envelope.verify(name, envelope.Data)
+}
+
+func (a *ProtectedApi) DeleteBinaryValue(id string) error {
+	return a.DeleteBinaryValueContext(context.Background(), id)
+}
+
+// DeleteBinaryValueContext deletes the binary value id together with its chunks
+func (a *ProtectedApi) DeleteBinaryValueContext(ctx context.Context, id string) error {
+	value, err := a.GetValueByIdContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := a.DeleteValueContext(ctx, id); err != nil {
+		return err
+	}
+	_, err = deleteValueVersions(ctx, a.client, value.Name+valueChunkSegment)
+	return err
+}
@@ -0,0 +1,169 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cryptvault-cloud/api"
+)
+
+func TestBinaryValues(t *testing.T) {
+	_, operator, _ := newTestVault(t, api.WithBinaryChunkSize(16))
+	ctx := context.Background()
+
+	small := []byte{0x00, 0xff, 0x10}
+	if _, err := operator.AddBinaryValueContext(ctx, "VALUES.small", small); err != nil {
+		t.Fatalf("AddBinaryValue() error = %v", err)
+	}
+	if got, err := operator.GetBinaryValueContext(ctx, "VALUES.small"); err != nil || !bytes.Equal(got, small) {
+		t.Errorf("GetBinaryValue() = %v, %v, want %v", got, err, small)
+	}
+
+	large := bytes.Repeat([]byte("0123456789"), 10)
+	id, err := operator.AddBinaryValueContext(ctx, "VALUES.large", large)
+	if err != nil {
+		t.Fatalf("AddBinaryValue() error = %v", err)
+	}
+	got, err := operator.GetBinaryValueContext(ctx, "VALUES.large")
+	if err != nil || !bytes.Equal(got, large) {
+		t.Errorf("GetBinaryValue() = %q, %v, want %q", got, err, large)
+	}
+
+	list, err := operator.ListValuesContext(ctx, nil, nil, api.Page{})
+	if err != nil {
+		t.Fatalf("ListValues() error = %v", err)
+	}
+	if list.TotalCount != 2 {
+		t.Errorf("ListValues() total = %d, want 2 without chunks", list.TotalCount)
+	}
+	values, err := operator.GetIdentityValuesByPatternContext(ctx, "VALUES.>")
+	if err != nil {
+		t.Fatalf("GetIdentityValuesByPattern() error = %v", err)
+	}
+	if len(values) != 2 || api.ValueKindOf(values["VALUES.large"]) != api.ValueKindBinary {
+		t.Errorf("GetIdentityValuesByPattern() = %d values, want 2 binary values without chunks", len(values))
+	}
+
+	if _, err := operator.AddValue("VALUES.plain", "text", api.ValueTypeString); err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	if _, err := operator.GetBinaryValueContext(ctx, "VALUES.plain"); !errors.Is(err, api.ErrValueTypeMismatch) {
+		t.Errorf("GetBinaryValue() of string value error = %v, want ErrValueTypeMismatch", err)
+	}
+
+	chunks := chunkNames(t, operator, "VALUES.large")
+	if len(chunks) != 7 {
+		t.Fatalf("AddBinaryValue() stored %d chunks, want 7", len(chunks))
+	}
+	if err := operator.DeleteBinaryValueContext(ctx, id); err != nil {
+		t.Fatalf("DeleteBinaryValue() error = %v", err)
+	}
+	for _, name := range chunks {
+		if _, err := operator.GetValueByNameContext(ctx, name); !errors.Is(err, api.ErrValueNotFound) {
+			t.Errorf("GetValueByNameContext(%s) after DeleteBinaryValue() error = %v, want ErrValueNotFound", name, err)
+		}
+	}
+}
+
+func TestBinaryValueMissingChunk(t *testing.T) {
+	_, operator, _ := newTestVault(t, api.WithBinaryChunkSize(4))
+	ctx := context.Background()
+
+	if _, err := operator.AddBinaryValueContext(ctx, "VALUES.blob", []byte("chunked blob")); err != nil {
+		t.Fatalf("AddBinaryValue() error = %v", err)
+	}
+	chunks := chunkNames(t, operator, "VALUES.blob")
+	if len(chunks) != 3 {
+		t.Fatalf("AddBinaryValue() stored %d chunks, want 3", len(chunks))
+	}
+	chunk, err := operator.GetValueByNameContext(ctx, chunks[0])
+	if err != nil {
+		t.Fatalf("GetValueByNameContext() error = %v", err)
+	}
+	if err := operator.DeleteValueContext(ctx, chunk.Id); err != nil {
+		t.Fatalf("DeleteValueContext() error = %v", err)
+	}
+	if _, err := operator.GetBinaryValueContext(ctx, "VALUES.blob"); !errors.Is(err, api.ErrBinaryCorrupted) {
+		t.Errorf("GetBinaryValue() with missing chunk error = %v, want ErrBinaryCorrupted", err)
+	}
+}
+
+// chunkNames returns the names of the chunks of the binary value name as recorded in its envelope
+func chunkNames(t *testing.T, operator api.ProtectedApiHandler, name string) []string {
+	t.Helper()
+	value, err := operator.GetIdentityValueByName(name)
+	if err != nil {
+		t.Fatalf("GetIdentityValueByName() error = %v", err)
+	}
+	var envelope struct {
+		Chunks   int    `json:"chunks"`
+		ChunkSet string `json:"chunkSet"`
+	}
+	if err := json.Unmarshal([]byte(value.Value), &envelope); err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0, envelope.Chunks)
+	for i := 0; i < envelope.Chunks; i++ {
+		names = append(names, fmt.Sprintf("%s._chunk.%s.%d", name, envelope.ChunkSet, i))
+	}
+	return names
+}
+
+func TestExportImportBinaryValues(t *testing.T) {
+	_, source, _ := newTestVault(t, api.WithBinaryChunkSize(8))
+	_, target, _ := newTestVault(t, api.WithBinaryChunkSize(16))
+	ctx := context.Background()
+
+	data := bytes.Repeat([]byte("binary"), 10)
+	if _, err := source.AddBinaryValueContext(ctx, "VALUES.a.blob", data); err != nil {
+		t.Fatalf("AddBinaryValue() error = %v", err)
+	}
+	if _, err := target.AddBinaryValueContext(ctx, "VALUES.a.blob", []byte("existing value which is chunked")); err != nil {
+		t.Fatalf("AddBinaryValue() error = %v", err)
+	}
+	replaced := chunkNames(t, target, "VALUES.a.blob")
+	recipient, err := target.CreateIdentity("migration", rightInputs(t, "(rwd)VALUES.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	archive, err := source.ExportValuesContext(ctx, "VALUES.a.>", &recipient.PrivateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("ExportValues() error = %v", err)
+	}
+	if len(archive.Values) != 1 || archive.Values[0].Name != "VALUES.a.blob" {
+		t.Fatalf("ExportValues() exported %d values, want the binary value without chunks", len(archive.Values))
+	}
+
+	decrypter := api.ImportOptions{Decrypter: api.NewPrivateKeySigner(recipient.PrivateKey)}
+	for _, policy := range []api.ImportConflictPolicy{api.ImportRename, api.ImportOverwrite} {
+		opts := decrypter
+		opts.Conflict = policy
+		results, err := target.ImportValuesContext(ctx, archive, opts)
+		if err != nil {
+			t.Fatalf("ImportValues(%s) error = %v", policy, err)
+		}
+		got, err := target.GetBinaryValueContext(ctx, results[0].TargetName)
+		if err != nil || !bytes.Equal(got, data) {
+			t.Errorf("GetBinaryValue(%s) after ImportValues(%s) = %q, %v, want %q", results[0].TargetName, policy, got, err, data)
+		}
+		if chunks := chunkNames(t, target, results[0].TargetName); len(chunks) != 4 {
+			t.Errorf("ImportValues(%s) stored %d chunks, want 4", policy, len(chunks))
+		}
+	}
+	for _, name := range replaced {
+		if _, err := target.GetValueByNameContext(ctx, name); !errors.Is(err, api.ErrValueNotFound) {
+			t.Errorf("chunk %s of overwritten value was not deleted, error = %v", name, err)
+		}
+	}
+	list, err := target.ListValuesContext(ctx, nil, nil, api.Page{})
+	if err != nil {
+		t.Fatalf("ListValues() error = %v", err)
+	}
+	if list.TotalCount != 2 {
+		t.Errorf("ListValues() total = %d, want VALUES.a.blob and VALUES.a.blob-1", list.TotalCount)
+	}
+}
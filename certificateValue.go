@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// CertificateBundle is a PEM certificate bundle read by GetCertificateValue
+type CertificateBundle struct {
+	// Certificates are ordered as in the bundle, usually the leaf first
+	Certificates []*x509.Certificate
+	PEM          []byte
+	// NotBefore and NotAfter are the range all certificates of the bundle are valid in
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// ExpiresWithin reports whether a certificate of the bundle expires within d
+func (b *CertificateBundle) ExpiresWithin(d time.Duration) bool {
+	return time.Now().Add(d).After(b.NotAfter)
+}
+
+type certificateEnvelope struct {
+	Kind      ValueKind `json:"kind"`
+	PEM       string    `json:"pem"`
+	Subject   string    `json:"subject"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+	// SHA256 is the fingerprint of the first certificate
+	SHA256 string `json:"sha256"`
+}
+
+func (a *ProtectedApi) AddCertificateValue(name string, pemBundle []byte) (string, error) {
+	return a.AddCertificateValueContext(context.Background(), name, pemBundle)
+}
+
+// AddCertificateValueContext adds the PEM encoded certificate bundle as value with name. The bundle must only
+// contain CERTIFICATE blocks, it is parsed before it is encrypted and fails with ErrInvalidCertificate otherwise.
+// Subject and expiry are stored with the bundle. Private keys belong in AddKeyPairValue.
+func (a *ProtectedApi) AddCertificateValueContext(ctx context.Context, name string, pemBundle []byte) (string, error) {
+	certificates, err := parseCertificates(pemBundle)
+	if err != nil {
+		return "", err
+	}
+	notBefore, notAfter := certificateValidity(certificates)
+	fingerprint := sha256.Sum256(certificates[0].Raw)
+	return a.addEnvelope(ctx, name, &certificateEnvelope{
+		Kind:      ValueKindCertificate,
+		PEM:       string(pemBundle),
+		Subject:   certificates[0].Subject.String(),
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+		SHA256:    hex.EncodeToString(fingerprint[:]),
+	})
+}
+
+func (a *ProtectedApi) GetCertificateValue(name string) (*CertificateBundle, error) {
+	return a.GetCertificateValueContext(context.Background(), name)
+}
+
+// GetCertificateValueContext decrypts and parses the certificate bundle with name.
+// It fails with ErrValueTypeMismatch if the value was not added by AddCertificateValue.
+func (a *ProtectedApi) GetCertificateValueContext(ctx context.Context, name string) (*CertificateBundle, error) {
+	envelope := new(certificateEnvelope)
+	if err := a.getEnvelope(ctx, name, ValueKindCertificate, envelope); err != nil {
+		return nil, err
+	}
+	certificates, err := parseCertificates([]byte(envelope.PEM))
+	if err != nil {
+		return nil, err
+	}
+	notBefore, notAfter := certificateValidity(certificates)
+	return &CertificateBundle{
+		Certificates: certificates,
+		PEM:          []byte(envelope.PEM),
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}, nil
+}
+
+func parseCertificates(pemBundle []byte) ([]*x509.Certificate, error) {
+	certificates := make([]*x509.Certificate, 0)
+	for rest := pemBundle; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("%w: unexpected PEM block %s", ErrInvalidCertificate, block.Type)
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCertificate, err)
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("%w: no PEM certificate found", ErrInvalidCertificate)
+	}
+	return certificates, nil
+}
+
+// certificateValidity returns the latest NotBefore and earliest NotAfter of certificates
+func certificateValidity(certificates []*x509.Certificate) (notBefore, notAfter time.Time) {
+	for i, c := range certificates {
+		if i == 0 || c.NotBefore.After(notBefore) {
+			notBefore = c.NotBefore
+		}
+		if i == 0 || c.NotAfter.Before(notAfter) {
+			notAfter = c.NotAfter
+		}
+	}
+	return notBefore, notAfter
+}
@@ -0,0 +1,89 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/cryptvault-cloud/api"
+)
+
+func selfSignedCertificate(t *testing.T, name string, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour).Truncate(time.Second),
+		NotAfter:     notAfter.Truncate(time.Second),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCertificateValues(t *testing.T) {
+	_, operator, _ := newTestVault(t)
+	ctx := context.Background()
+
+	leafExpiry := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	bundle := append(selfSignedCertificate(t, "leaf", leafExpiry), selfSignedCertificate(t, "ca", time.Now().Add(365*24*time.Hour))...)
+	if _, err := operator.AddCertificateValueContext(ctx, "VALUES.tls", bundle); err != nil {
+		t.Fatalf("AddCertificateValue() error = %v", err)
+	}
+	got, err := operator.GetCertificateValueContext(ctx, "VALUES.tls")
+	if err != nil {
+		t.Fatalf("GetCertificateValue() error = %v", err)
+	}
+	if len(got.Certificates) != 2 || got.Certificates[0].Subject.CommonName != "leaf" {
+		t.Errorf("GetCertificateValue() = %d certificates, want leaf and ca", len(got.Certificates))
+	}
+	if !got.NotAfter.Equal(leafExpiry) {
+		t.Errorf("GetCertificateValue() NotAfter = %v, want %v", got.NotAfter, leafExpiry)
+	}
+	if !got.ExpiresWithin(48*time.Hour) || got.ExpiresWithin(time.Hour) {
+		t.Errorf("ExpiresWithin() does not match expiry %v", got.NotAfter)
+	}
+
+	key := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: mustPKCS8(t)})
+	for name, pemBundle := range map[string][]byte{
+		"empty":       nil,
+		"garbage":     []byte("not a certificate"),
+		"private key": append(selfSignedCertificate(t, "leaf", leafExpiry), key...),
+		"broken":      pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("broken")}),
+	} {
+		if _, err := operator.AddCertificateValueContext(ctx, "VALUES.invalid", pemBundle); !errors.Is(err, api.ErrInvalidCertificate) {
+			t.Errorf("AddCertificateValue() with %s error = %v, want ErrInvalidCertificate", name, err)
+		}
+	}
+	if _, err := operator.GetValueByNameContext(ctx, "VALUES.invalid"); err == nil {
+		t.Error("AddCertificateValue() stored an invalid bundle")
+	}
+}
+
+func mustPKCS8(t *testing.T) []byte {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	return der
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+// ConfigTag is the struct tag LoadConfig maps value names with
+const ConfigTag = "vault"
+
+// ConfigReport lists the keys LoadConfig could not match. Keys are relative to the prefix, f.e.: db.user
+type ConfigReport struct {
+	// Missing lists the keys of fields without a value, fields tagged optional are left out
+	Missing []string
+	// Extra lists the keys of values no field was tagged for
+	Extra []string
+}
+
+// OK reports whether every field got a value and every value was used
+func (r *ConfigReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+func (a *ProtectedApi) LoadConfig(prefix string, target any) (*ConfigReport, error) {
+	return a.LoadConfigContext(context.Background(), prefix, target)
+}
+
+// LoadConfigContext fetches and decrypts all values below prefix, f.e.: VALUES.prod.payments, and stores them in
+// the struct target points to, VALUES or an empty prefix loads all values. Fields are mapped by their tag
+// relative to the prefix:
+//
+//	type Config struct {
+//		ApiKey  string        `vault:"key"`
+//		Timeout time.Duration `vault:"timeout,optional"`
+//		DB      struct {
+//			User string `vault:"user"`
+//			Pass string `vault:"pass"`
+//		} `vault:"db"`
+//	}
+//
+// A nested struct is filled from the values below its key, unless a value exists at its key itself.
+// Values of type JSON are unmarshaled into the field, String values are converted to strings, numbers,
+// bools, time.Duration or any encoding.TextUnmarshaler. Fields without tag are left untouched.
+// Missing and unused keys are only reported, an error is returned if a value can not be converted.
+func (a *ProtectedApi) LoadConfigContext(ctx context.Context, prefix string, target any) (*ConfigReport, error) {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config target must be a non nil pointer to a struct, got %T", target)
+	}
+	prefix = strings.TrimSuffix(prefix, ".")
+	switch {
+	case prefix == "" || prefix+"." == helper.ValuesPrefix:
+		prefix = helper.ValuesPrefix
+	case strings.HasPrefix(prefix, helper.ValuesPrefix):
+		prefix += "."
+	default:
+		prefix = helper.ValuesPrefix + prefix + "."
+	}
+	values, err := a.GetIdentityValuesByPatternContext(ctx, prefix+">")
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*IdentityValue, len(values))
+	for name, v := range values {
+		if !strings.HasPrefix(name, helper.ValuesPrefix) {
+			name = helper.ValuesPrefix + name
+		}
+		byKey[strings.TrimPrefix(name, prefix)] = v
+	}
+
+	loader := &configLoader{values: byKey, used: make(map[string]bool), report: &ConfigReport{Missing: make([]string, 0), Extra: make([]string, 0)}}
+	if err := loader.loadStruct(rv.Elem(), ""); err != nil {
+		return nil, err
+	}
+	for key := range byKey {
+		if !loader.used[key] {
+			loader.report.Extra = append(loader.report.Extra, key)
+		}
+	}
+	sort.Strings(loader.report.Missing)
+	sort.Strings(loader.report.Extra)
+	return loader.report, nil
+}
+
+type configLoader struct {
+	values map[string]*IdentityValue
+	used   map[string]bool
+	report *ConfigReport
+}
+
+func (l *configLoader) loadStruct(rv reflect.Value, prefix string) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup(ConfigTag)
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			return fmt.Errorf("field %s of %s has no value name in tag %s", field.Name, rt, ConfigTag)
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		if err := l.loadField(rv.Field(i), key, options == "optional"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *configLoader) loadField(fv reflect.Value, key string, optional bool) error {
+	if value, ok := l.values[key]; ok {
+		l.used[key] = true
+		if err := setConfigValue(fv, value); err != nil {
+			return fmt.Errorf("config key %s: %w", key, err)
+		}
+		return nil
+	}
+	if isConfigSection(fv.Type()) {
+		if !l.hasChildren(key) {
+			if !optional {
+				l.report.Missing = append(l.report.Missing, key)
+			}
+			return nil
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		return l.loadStruct(fv, key)
+	}
+	if !optional {
+		l.report.Missing = append(l.report.Missing, key)
+	}
+	return nil
+}
+
+func (l *configLoader) hasChildren(key string) bool {
+	for k := range l.values {
+		if strings.HasPrefix(k, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// isConfigSection reports whether values of type t are loaded from the keys below the key of the field
+func isConfigSection(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	return !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// setConfigValue stores the secret of value in fv
+func setConfigValue(fv reflect.Value, value *IdentityValue) error {
+	if value.Type == ValueTypeJson {
+		return json.Unmarshal([]byte(value.Value), fv.Addr().Interface())
+	}
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+	if u, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value.Value))
+	}
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(value.Value)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value.Value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value.Value)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value.Value, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value.Value, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value.Value, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.Uint8 {
+			return errors.New("only values of type JSON can be loaded into slices")
+		}
+		fv.SetBytes([]byte(value.Value))
+	default:
+		return fmt.Errorf("can not load value of type %s into %s", value.Type, fv.Type())
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package api_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cryptvault-cloud/api"
+)
+
+type paymentsConfig struct {
+	Key     string        `vault:"key"`
+	Timeout time.Duration `vault:"timeout"`
+	Retries int           `vault:"retries"`
+	Limits  struct {
+		Max int `json:"max"`
+	} `vault:"limits"`
+	DB struct {
+		User string `vault:"user"`
+		Pass []byte `vault:"pass"`
+	} `vault:"db"`
+	Region  string `vault:"region"`
+	Comment string `vault:"comment,optional"`
+	Local   string
+}
+
+func TestLoadConfig(t *testing.T) {
+	_, operator, _ := newTestVault(t)
+	ctx := context.Background()
+
+	values := []struct {
+		name      string
+		value     string
+		valueType api.ValueType
+	}{
+		{"VALUES.prod.payments.key", "secret", api.ValueTypeString},
+		{"VALUES.prod.payments.timeout", "5s", api.ValueTypeString},
+		{"VALUES.prod.payments.retries", "3", api.ValueTypeString},
+		{"VALUES.prod.payments.limits", `{"max": 10}`, api.ValueTypeJson},
+		{"VALUES.prod.payments.db.user", "payments", api.ValueTypeString},
+		{"VALUES.prod.payments.db.pass", "hunter2", api.ValueTypeString},
+		{"VALUES.prod.payments.unused", "x", api.ValueTypeString},
+		{"VALUES.prod.shipping.key", "other", api.ValueTypeString},
+	}
+	for _, v := range values {
+		if _, err := operator.AddValue(v.name, v.value, v.valueType); err != nil {
+			t.Fatalf("AddValue() error = %v", err)
+		}
+	}
+
+	var config paymentsConfig
+	report, err := operator.LoadConfigContext(ctx, "VALUES.prod.payments", &config)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if config.Key != "secret" || config.Timeout != 5*time.Second || config.Retries != 3 || config.Limits.Max != 10 {
+		t.Errorf("LoadConfig() = %+v", config)
+	}
+	if config.DB.User != "payments" || string(config.DB.Pass) != "hunter2" {
+		t.Errorf("LoadConfig() db = %+v", config.DB)
+	}
+	if !reflect.DeepEqual(report.Missing, []string{"region"}) || !reflect.DeepEqual(report.Extra, []string{"unused"}) {
+		t.Errorf("LoadConfig() report = %+v, want region missing and unused extra", report)
+	}
+
+	var invalid struct {
+		Key int `vault:"key"`
+	}
+	if _, err := operator.LoadConfigContext(ctx, "prod.payments", &invalid); err == nil {
+		t.Error("LoadConfig() of string into int error = nil")
+	}
+	if _, err := operator.LoadConfigContext(ctx, "prod.payments", config); err == nil {
+		t.Error("LoadConfig() into struct value error = nil")
+	}
+
+	var root struct {
+		Prod struct {
+			Shipping struct {
+				Key string `vault:"key"`
+			} `vault:"shipping"`
+		} `vault:"prod"`
+	}
+	for _, prefix := range []string{"VALUES", "VALUES.", ""} {
+		root.Prod.Shipping.Key = ""
+		if _, err := operator.LoadConfigContext(ctx, prefix, &root); err != nil {
+			t.Fatalf("LoadConfig(%q) error = %v", prefix, err)
+		}
+		if root.Prod.Shipping.Key != "other" {
+			t.Errorf("LoadConfig(%q) shipping key = %q, want other", prefix, root.Prod.Shipping.Key)
+		}
+	}
+}
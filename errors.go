@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+var (
+	ErrValueNotFound              = errors.New("value not found")
+	ErrIdentityNotFound           = errors.New("identity not found")
+	ErrVaultNotFound              = errors.New("vault not found")
+	ErrNothingCreated             = errors.New("add request succeeded but nothing was created")
+	ErrPermissionDenied           = errors.New("permission denied")
+	ErrIdentityValueMissing       = errors.New("identity value missing")
+	ErrInvalidValueKey            = errors.New("value key can not have wildcard symbols * or >")
+	ErrInvalidRightPattern        = errors.New("invalid right pattern")
+	ErrValueSharing               = errors.New("rights changed but sharing values failed")
+	ErrValueRotation              = errors.New("value rotation failed")
+	ErrValueVersionNotFound       = errors.New("value version not found")
+	ErrInvalidCreatorVerification = errors.New("invalid creator verification")
+	ErrUntrustedIdentity          = errors.New("untrusted identity")
+	ErrWatchUnsupported           = errors.New("watch transport not supported")
+	ErrArchiveVersion             = errors.New("unsupported value archive version")
+	ErrArchiveRecipient           = errors.New("value archive is encrypted for another recipient")
+	ErrInvalidJSON                = errors.New("value is not valid JSON")
+	ErrValueTypeMismatch          = errors.New("value type mismatch")
+	ErrSchemaValidation           = errors.New("value does not match schema")
+	ErrBinaryCorrupted            = errors.New("binary value is corrupted")
+	ErrInvalidCertificate         = errors.New("invalid certificate")
+	ErrInvalidKeyPair             = errors.New("invalid key pair")
+)
+
+// PermissionDeniedError is returned if the identity behind the ProtectedApi has not the right to
+// access a resource. It matches ErrPermissionDenied with errors.Is.
+type PermissionDeniedError struct {
+	IdentityId string
+	Target     RightTarget
+	Direction  Directions
+	Resource   string
+}
+
+func (e *PermissionDeniedError) Error() string {
+	return fmt.Sprintf("permission denied: identity %s has no %s right for %s %s", e.IdentityId, e.Direction, e.Target, e.Resource)
+}
+
+func (e *PermissionDeniedError) Is(target error) bool {
+	return target == ErrPermissionDenied
+}
+
+// IdentityValueMissingError is returned if a value has no passframe encrypted for the identity.
+// It matches ErrIdentityValueMissing with errors.Is.
+type IdentityValueMissingError struct {
+	IdentityId string
+}
+
+func (e *IdentityValueMissingError) Error() string {
+	return fmt.Sprintf("identity value missing: given identity %s not found at saved values", e.IdentityId)
+}
+
+func (e *IdentityValueMissingError) Is(target error) bool {
+	return target == ErrIdentityValueMissing
+}
+
+// UntrustedIdentity is an identity whose creator chain could not be verified
+type UntrustedIdentity struct {
+	IdentityId string
+	Reason     string
+}
+
+// UntrustedIdentitiesError is returned before a value is encrypted if the public key of any identity
+// can not be verified by its creator chain up to an operator. It matches ErrUntrustedIdentity with errors.Is.
+type UntrustedIdentitiesError struct {
+	Identities []UntrustedIdentity
+}
+
+func (e *UntrustedIdentitiesError) Error() string {
+	identities := make([]string, 0, len(e.Identities))
+	for _, v := range e.Identities {
+		identities = append(identities, fmt.Sprintf("%s (%s)", v.IdentityId, v.Reason))
+	}
+	return fmt.Sprintf("untrusted identities: %s", strings.Join(identities, ", "))
+}
+
+func (e *UntrustedIdentitiesError) Is(target error) bool {
+	return target == ErrUntrustedIdentity
+}
+
+// GraphQLError wraps the error list returned by the graphql endpoint for the operation OpName.
+// Path and extensions of each error are available at Errors.
+type GraphQLError struct {
+	OpName string
+	Errors gqlerror.List
+}
+
+func (e *GraphQLError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, v := range e.Errors {
+		messages = append(messages, v.Error())
+	}
+	return fmt.Sprintf("graphql operation %s failed: %s", e.OpName, strings.Join(messages, "; "))
+}
+
+func (e *GraphQLError) Unwrap() error {
+	return e.Errors
+}
+
+// errorClient converts the gqlerror.List returned by genqlient into a *GraphQLError
+type errorClient struct {
+	wrapped graphql.Client
+}
+
+func (c *errorClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	err := c.wrapped.MakeRequest(ctx, req, resp)
+	var list gqlerror.List
+	if errors.As(err, &list) {
+		return &GraphQLError{OpName: req.OpName, Errors: list}
+	}
+	return err
+}
+
+// HTTPError is returned if the graphql endpoint answers with an error status
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       string
+	// RetryAfter is the wait time requested by the Retry-After header, zero if not set
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("returned error %s: %s", e.Status, e.Body)
+}
+
+// statusTransport converts error responses into an *HTTPError
+type statusTransport struct {
+	wrapped http.RoundTripper
+}
+
+func (t *statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.wrapped.RoundTrip(req)
+	if err != nil || resp.StatusCode < http.StatusBadRequest {
+		return resp, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		body = []byte(fmt.Sprintf("<unreadable: %v>", err))
+	}
+	return nil, &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       string(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// parseRetryAfter parses the Retry-After header given in seconds or as http date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type clientFunc func(ctx context.Context, req *graphql.Request, resp *graphql.Response) error
+
+func (f clientFunc) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	return f(ctx, req, resp)
+}
+
+func TestErrorClientWrapsGraphQLErrors(t *testing.T) {
+	client := &errorClient{wrapped: clientFunc(func(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+		return gqlerror.List{gqlerror.Errorf("not allowed")}
+	})}
+	err := client.MakeRequest(context.Background(), &graphql.Request{OpName: "getValue"}, &graphql.Response{})
+
+	var gqlErr *GraphQLError
+	if !errors.As(err, &gqlErr) {
+		t.Fatalf("errors.As(%v, *GraphQLError) = false", err)
+	}
+	if gqlErr.OpName != "getValue" || len(gqlErr.Errors) != 1 {
+		t.Errorf("unexpected GraphQLError %+v", gqlErr)
+	}
+	var list gqlerror.List
+	if !errors.As(err, &list) {
+		t.Errorf("errors.As(%v, gqlerror.List) = false", err)
+	}
+}
+
+func TestTypedErrorsMatchSentinels(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+	}{
+		{name: "permission denied", err: &PermissionDeniedError{Target: RightTargetValues, Direction: DirectionsWrite}, target: ErrPermissionDenied},
+		{name: "identity value missing", err: &IdentityValueMissingError{IdentityId: "id"}, target: ErrIdentityValueMissing},
+		{name: "invalid right pattern", err: func() error { _, err := GetRightDescriptionByString("VALUE.a"); return err }(), target: ErrInvalidRightPattern},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, tt.target) {
+				t.Errorf("errors.Is(%v, %v) = false", tt.err, tt.target)
+			}
+		})
+	}
+}
+
+func TestAddReportsNothingCreated(t *testing.T) {
+	// the server answers the mutation without any affected entry
+	a := &ProtectedApi{client: clientFunc(func(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+		return nil
+	})}
+	_, err := a.AddIdentityValueContext(context.Background(), IdentityValueInput{ValueID: "value", IdentityID: "identity", Passframe: "passframe"})
+	if !errors.Is(err, ErrNothingCreated) {
+		t.Errorf("AddIdentityValueContext() error = %v, want ErrNothingCreated", err)
+	}
+	if errors.Is(err, ErrValueNotFound) {
+		t.Errorf("AddIdentityValueContext() error = %v matches ErrValueNotFound", err)
+	}
+}
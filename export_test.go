@@ -0,0 +1,23 @@
+package api
+
+import "net/http"
+
+// Helpers shared by the tests of this package and of the api_test package
+
+// RoundTripFunc replaces the transport of a http.Client by a function
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// CountingSigner counts the passframes it decrypted, it stands for a signer outside of the process
+type CountingSigner struct {
+	*PrivateKeySigner
+	Decrypted int
+}
+
+func (s *CountingSigner) Decrypt(passframe string) ([]byte, error) {
+	s.Decrypted++
+	return s.PrivateKeySigner.Decrypt(passframe)
+}
@@ -10,6 +10,34 @@ import (
 	"github.com/cryptvault-cloud/helper"
 )
 
+// Boolean Filter simple datatypes
+type BooleanFilterInput struct {
+	And     []*bool             `json:"and"`
+	Or      []*bool             `json:"or"`
+	Not     *BooleanFilterInput `json:"not,omitempty"`
+	Is      *bool               `json:"is"`
+	Null    *bool               `json:"null"`
+	NotNull *bool               `json:"notNull"`
+}
+
+// GetAnd returns BooleanFilterInput.And, and is useful for accessing the field via an interface.
+func (v *BooleanFilterInput) GetAnd() []*bool { return v.And }
+
+// GetOr returns BooleanFilterInput.Or, and is useful for accessing the field via an interface.
+func (v *BooleanFilterInput) GetOr() []*bool { return v.Or }
+
+// GetNot returns BooleanFilterInput.Not, and is useful for accessing the field via an interface.
+func (v *BooleanFilterInput) GetNot() *BooleanFilterInput { return v.Not }
+
+// GetIs returns BooleanFilterInput.Is, and is useful for accessing the field via an interface.
+func (v *BooleanFilterInput) GetIs() *bool { return v.Is }
+
+// GetNull returns BooleanFilterInput.Null, and is useful for accessing the field via an interface.
+func (v *BooleanFilterInput) GetNull() *bool { return v.Null }
+
+// GetNotNull returns BooleanFilterInput.NotNull, and is useful for accessing the field via an interface.
+func (v *BooleanFilterInput) GetNotNull() *bool { return v.NotNull }
+
 type Directions string
 
 const (
@@ -18,6 +46,158 @@ const (
 	DirectionsDelete Directions = "delete"
 )
 
+// ID Filter simple datatypes
+type IDFilterInput struct {
+	And     []*string      `json:"and"`
+	Or      []*string      `json:"or"`
+	Not     *IDFilterInput `json:"not,omitempty"`
+	Eq      *string        `json:"eq"`
+	Ne      *string        `json:"ne"`
+	Null    *bool          `json:"null"`
+	NotNull *bool          `json:"notNull"`
+	In      []*string      `json:"in"`
+	Notin   []*string      `json:"notin"`
+}
+
+// GetAnd returns IDFilterInput.And, and is useful for accessing the field via an interface.
+func (v *IDFilterInput) GetAnd() []*string { return v.And }
+
+// GetOr returns IDFilterInput.Or, and is useful for accessing the field via an interface.
+func (v *IDFilterInput) GetOr() []*string { return v.Or }
+
+// GetNot returns IDFilterInput.Not, and is useful for accessing the field via an interface.
+func (v *IDFilterInput) GetNot() *IDFilterInput { return v.Not }
+
+// GetEq returns IDFilterInput.Eq, and is useful for accessing the field via an interface.
+func (v *IDFilterInput) GetEq() *string { return v.Eq }
+
+// GetNe returns IDFilterInput.Ne, and is useful for accessing the field via an interface.
+func (v *IDFilterInput) GetNe() *string { return v.Ne }
+
+// GetNull returns IDFilterInput.Null, and is useful for accessing the field via an interface.
+func (v *IDFilterInput) GetNull() *bool { return v.Null }
+
+// GetNotNull returns IDFilterInput.NotNull, and is useful for accessing the field via an interface.
+func (v *IDFilterInput) GetNotNull() *bool { return v.NotNull }
+
+// GetIn returns IDFilterInput.In, and is useful for accessing the field via an interface.
+func (v *IDFilterInput) GetIn() []*string { return v.In }
+
+// GetNotin returns IDFilterInput.Notin, and is useful for accessing the field via an interface.
+func (v *IDFilterInput) GetNotin() []*string { return v.Notin }
+
+// Filter input selection for Identity
+// Can be used f.e.: by queryIdentity
+type IdentityFiltersInput struct {
+	Id                  *StringFilterInput      `json:"id,omitempty"`
+	Name                *StringFilterInput      `json:"name,omitempty"`
+	Rights              *RightFiltersInput      `json:"rights,omitempty"`
+	VaultID             *StringFilterInput      `json:"vaultID,omitempty"`
+	Vault               *VaultFiltersInput      `json:"vault,omitempty"`
+	CreatorVerification *StringFilterInput      `json:"creatorVerification,omitempty"`
+	IsOperator          *BooleanFilterInput     `json:"isOperator,omitempty"`
+	CreatedAt           *TimeFilterInput        `json:"createdAt,omitempty"`
+	UpdatedAt           *TimeFilterInput        `json:"updatedAt,omitempty"`
+	DeletedAt           *TimeFilterInput        `json:"deletedAt,omitempty"`
+	And                 []*IdentityFiltersInput `json:"and,omitempty"`
+	Or                  []*IdentityFiltersInput `json:"or,omitempty"`
+	Not                 *IdentityFiltersInput   `json:"not,omitempty"`
+}
+
+// GetId returns IdentityFiltersInput.Id, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetId() *StringFilterInput { return v.Id }
+
+// GetName returns IdentityFiltersInput.Name, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetName() *StringFilterInput { return v.Name }
+
+// GetRights returns IdentityFiltersInput.Rights, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetRights() *RightFiltersInput { return v.Rights }
+
+// GetVaultID returns IdentityFiltersInput.VaultID, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetVaultID() *StringFilterInput { return v.VaultID }
+
+// GetVault returns IdentityFiltersInput.Vault, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetVault() *VaultFiltersInput { return v.Vault }
+
+// GetCreatorVerification returns IdentityFiltersInput.CreatorVerification, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetCreatorVerification() *StringFilterInput {
+	return v.CreatorVerification
+}
+
+// GetIsOperator returns IdentityFiltersInput.IsOperator, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetIsOperator() *BooleanFilterInput { return v.IsOperator }
+
+// GetCreatedAt returns IdentityFiltersInput.CreatedAt, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetCreatedAt() *TimeFilterInput { return v.CreatedAt }
+
+// GetUpdatedAt returns IdentityFiltersInput.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetUpdatedAt() *TimeFilterInput { return v.UpdatedAt }
+
+// GetDeletedAt returns IdentityFiltersInput.DeletedAt, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetDeletedAt() *TimeFilterInput { return v.DeletedAt }
+
+// GetAnd returns IdentityFiltersInput.And, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetAnd() []*IdentityFiltersInput { return v.And }
+
+// GetOr returns IdentityFiltersInput.Or, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetOr() []*IdentityFiltersInput { return v.Or }
+
+// GetNot returns IdentityFiltersInput.Not, and is useful for accessing the field via an interface.
+func (v *IdentityFiltersInput) GetNot() *IdentityFiltersInput { return v.Not }
+
+// Filter input selection for IdentityValue
+// Can be used f.e.: by queryIdentityValue
+type IdentityValueFiltersInput struct {
+	Id         *IDFilterInput               `json:"id,omitempty"`
+	ValueID    *StringFilterInput           `json:"valueID,omitempty"`
+	Value      *ValueFiltersInput           `json:"value,omitempty"`
+	IdentityID *StringFilterInput           `json:"identityID,omitempty"`
+	Identity   *IdentityFiltersInput        `json:"identity,omitempty"`
+	Passframe  *StringFilterInput           `json:"passframe,omitempty"`
+	CreatedAt  *TimeFilterInput             `json:"createdAt,omitempty"`
+	UpdatedAt  *TimeFilterInput             `json:"updatedAt,omitempty"`
+	DeletedAt  *TimeFilterInput             `json:"deletedAt,omitempty"`
+	And        []*IdentityValueFiltersInput `json:"and,omitempty"`
+	Or         []*IdentityValueFiltersInput `json:"or,omitempty"`
+	Not        *IdentityValueFiltersInput   `json:"not,omitempty"`
+}
+
+// GetId returns IdentityValueFiltersInput.Id, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetId() *IDFilterInput { return v.Id }
+
+// GetValueID returns IdentityValueFiltersInput.ValueID, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetValueID() *StringFilterInput { return v.ValueID }
+
+// GetValue returns IdentityValueFiltersInput.Value, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetValue() *ValueFiltersInput { return v.Value }
+
+// GetIdentityID returns IdentityValueFiltersInput.IdentityID, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetIdentityID() *StringFilterInput { return v.IdentityID }
+
+// GetIdentity returns IdentityValueFiltersInput.Identity, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetIdentity() *IdentityFiltersInput { return v.Identity }
+
+// GetPassframe returns IdentityValueFiltersInput.Passframe, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetPassframe() *StringFilterInput { return v.Passframe }
+
+// GetCreatedAt returns IdentityValueFiltersInput.CreatedAt, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetCreatedAt() *TimeFilterInput { return v.CreatedAt }
+
+// GetUpdatedAt returns IdentityValueFiltersInput.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetUpdatedAt() *TimeFilterInput { return v.UpdatedAt }
+
+// GetDeletedAt returns IdentityValueFiltersInput.DeletedAt, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetDeletedAt() *TimeFilterInput { return v.DeletedAt }
+
+// GetAnd returns IdentityValueFiltersInput.And, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetAnd() []*IdentityValueFiltersInput { return v.And }
+
+// GetOr returns IdentityValueFiltersInput.Or, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetOr() []*IdentityValueFiltersInput { return v.Or }
+
+// GetNot returns IdentityValueFiltersInput.Not, and is useful for accessing the field via an interface.
+func (v *IdentityValueFiltersInput) GetNot() *IdentityValueFiltersInput { return v.Not }
+
 // IdentityValue Input value to add new IdentityValue
 type IdentityValueInput struct {
 	ValueID    string `json:"valueID"`
@@ -50,6 +230,131 @@ func (v *IdentityValuePatch) GetIdentityID() *string { return v.IdentityID }
 // GetPassframe returns IdentityValuePatch.Passframe, and is useful for accessing the field via an interface.
 func (v *IdentityValuePatch) GetPassframe() *string { return v.Passframe }
 
+// Filter between start and end (start > value < end)
+type IntFilterBetween struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// GetStart returns IntFilterBetween.Start, and is useful for accessing the field via an interface.
+func (v *IntFilterBetween) GetStart() int { return v.Start }
+
+// GetEnd returns IntFilterBetween.End, and is useful for accessing the field via an interface.
+func (v *IntFilterBetween) GetEnd() int { return v.End }
+
+// Int Filter simple datatypes
+type IntFilterInput struct {
+	And     []*int            `json:"and"`
+	Or      []*int            `json:"or"`
+	Not     *IntFilterInput   `json:"not,omitempty"`
+	Eq      *int              `json:"eq"`
+	Ne      *int              `json:"ne"`
+	Gt      *int              `json:"gt"`
+	Gte     *int              `json:"gte"`
+	Lt      *int              `json:"lt"`
+	Lte     *int              `json:"lte"`
+	Null    *bool             `json:"null"`
+	NotNull *bool             `json:"notNull"`
+	In      []*int            `json:"in"`
+	NotIn   []*int            `json:"notIn"`
+	Between *IntFilterBetween `json:"between,omitempty"`
+}
+
+// GetAnd returns IntFilterInput.And, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetAnd() []*int { return v.And }
+
+// GetOr returns IntFilterInput.Or, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetOr() []*int { return v.Or }
+
+// GetNot returns IntFilterInput.Not, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetNot() *IntFilterInput { return v.Not }
+
+// GetEq returns IntFilterInput.Eq, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetEq() *int { return v.Eq }
+
+// GetNe returns IntFilterInput.Ne, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetNe() *int { return v.Ne }
+
+// GetGt returns IntFilterInput.Gt, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetGt() *int { return v.Gt }
+
+// GetGte returns IntFilterInput.Gte, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetGte() *int { return v.Gte }
+
+// GetLt returns IntFilterInput.Lt, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetLt() *int { return v.Lt }
+
+// GetLte returns IntFilterInput.Lte, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetLte() *int { return v.Lte }
+
+// GetNull returns IntFilterInput.Null, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetNull() *bool { return v.Null }
+
+// GetNotNull returns IntFilterInput.NotNull, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetNotNull() *bool { return v.NotNull }
+
+// GetIn returns IntFilterInput.In, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetIn() []*int { return v.In }
+
+// GetNotIn returns IntFilterInput.NotIn, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetNotIn() []*int { return v.NotIn }
+
+// GetBetween returns IntFilterInput.Between, and is useful for accessing the field via an interface.
+func (v *IntFilterInput) GetBetween() *IntFilterBetween { return v.Between }
+
+// Filter input selection for Right
+// Can be used f.e.: by queryRight
+type RightFiltersInput struct {
+	Id                *IDFilterInput        `json:"id,omitempty"`
+	Target            *StringFilterInput    `json:"target,omitempty"`
+	Right             *StringFilterInput    `json:"right,omitempty"`
+	RightValuePattern *StringFilterInput    `json:"rightValuePattern,omitempty"`
+	IdentityID        *StringFilterInput    `json:"identityID,omitempty"`
+	Identity          *IdentityFiltersInput `json:"identity,omitempty"`
+	CreatedAt         *TimeFilterInput      `json:"createdAt,omitempty"`
+	UpdatedAt         *TimeFilterInput      `json:"updatedAt,omitempty"`
+	DeletedAt         *TimeFilterInput      `json:"deletedAt,omitempty"`
+	And               []*RightFiltersInput  `json:"and,omitempty"`
+	Or                []*RightFiltersInput  `json:"or,omitempty"`
+	Not               *RightFiltersInput    `json:"not,omitempty"`
+}
+
+// GetId returns RightFiltersInput.Id, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetId() *IDFilterInput { return v.Id }
+
+// GetTarget returns RightFiltersInput.Target, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetTarget() *StringFilterInput { return v.Target }
+
+// GetRight returns RightFiltersInput.Right, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetRight() *StringFilterInput { return v.Right }
+
+// GetRightValuePattern returns RightFiltersInput.RightValuePattern, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetRightValuePattern() *StringFilterInput { return v.RightValuePattern }
+
+// GetIdentityID returns RightFiltersInput.IdentityID, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetIdentityID() *StringFilterInput { return v.IdentityID }
+
+// GetIdentity returns RightFiltersInput.Identity, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetIdentity() *IdentityFiltersInput { return v.Identity }
+
+// GetCreatedAt returns RightFiltersInput.CreatedAt, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetCreatedAt() *TimeFilterInput { return v.CreatedAt }
+
+// GetUpdatedAt returns RightFiltersInput.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetUpdatedAt() *TimeFilterInput { return v.UpdatedAt }
+
+// GetDeletedAt returns RightFiltersInput.DeletedAt, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetDeletedAt() *TimeFilterInput { return v.DeletedAt }
+
+// GetAnd returns RightFiltersInput.And, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetAnd() []*RightFiltersInput { return v.And }
+
+// GetOr returns RightFiltersInput.Or, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetOr() []*RightFiltersInput { return v.Or }
+
+// GetNot returns RightFiltersInput.Not, and is useful for accessing the field via an interface.
+func (v *RightFiltersInput) GetNot() *RightFiltersInput { return v.Not }
+
 // Right Input value to add new Right
 type RightInput struct {
 	Target            RightTarget `json:"target"`
@@ -78,6 +383,266 @@ const (
 	RightTargetIdentities RightTarget = "identities"
 )
 
+// String Filter simple datatypes
+type StringFilterInput struct {
+	And          []*string          `json:"and"`
+	Or           []*string          `json:"or"`
+	Not          *StringFilterInput `json:"not,omitempty"`
+	Eq           *string            `json:"eq"`
+	Eqi          *string            `json:"eqi"`
+	Ne           *string            `json:"ne"`
+	StartsWith   *string            `json:"startsWith"`
+	EndsWith     *string            `json:"endsWith"`
+	Contains     *string            `json:"contains"`
+	NotContains  *string            `json:"notContains"`
+	Containsi    *string            `json:"containsi"`
+	NotContainsi *string            `json:"notContainsi"`
+	Null         *bool              `json:"null"`
+	NotNull      *bool              `json:"notNull"`
+	In           []*string          `json:"in"`
+	NotIn        []*string          `json:"notIn"`
+}
+
+// GetAnd returns StringFilterInput.And, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetAnd() []*string { return v.And }
+
+// GetOr returns StringFilterInput.Or, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetOr() []*string { return v.Or }
+
+// GetNot returns StringFilterInput.Not, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetNot() *StringFilterInput { return v.Not }
+
+// GetEq returns StringFilterInput.Eq, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetEq() *string { return v.Eq }
+
+// GetEqi returns StringFilterInput.Eqi, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetEqi() *string { return v.Eqi }
+
+// GetNe returns StringFilterInput.Ne, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetNe() *string { return v.Ne }
+
+// GetStartsWith returns StringFilterInput.StartsWith, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetStartsWith() *string { return v.StartsWith }
+
+// GetEndsWith returns StringFilterInput.EndsWith, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetEndsWith() *string { return v.EndsWith }
+
+// GetContains returns StringFilterInput.Contains, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetContains() *string { return v.Contains }
+
+// GetNotContains returns StringFilterInput.NotContains, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetNotContains() *string { return v.NotContains }
+
+// GetContainsi returns StringFilterInput.Containsi, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetContainsi() *string { return v.Containsi }
+
+// GetNotContainsi returns StringFilterInput.NotContainsi, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetNotContainsi() *string { return v.NotContainsi }
+
+// GetNull returns StringFilterInput.Null, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetNull() *bool { return v.Null }
+
+// GetNotNull returns StringFilterInput.NotNull, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetNotNull() *bool { return v.NotNull }
+
+// GetIn returns StringFilterInput.In, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetIn() []*string { return v.In }
+
+// GetNotIn returns StringFilterInput.NotIn, and is useful for accessing the field via an interface.
+func (v *StringFilterInput) GetNotIn() []*string { return v.NotIn }
+
+// Filter between start and end (start > value < end)
+type TimeFilterBetween struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// GetStart returns TimeFilterBetween.Start, and is useful for accessing the field via an interface.
+func (v *TimeFilterBetween) GetStart() time.Time { return v.Start }
+
+// GetEnd returns TimeFilterBetween.End, and is useful for accessing the field via an interface.
+func (v *TimeFilterBetween) GetEnd() time.Time { return v.End }
+
+// Time Filter simple datatypes
+type TimeFilterInput struct {
+	And     []*time.Time       `json:"and"`
+	Or      []*time.Time       `json:"or"`
+	Not     *TimeFilterInput   `json:"not,omitempty"`
+	Eq      *time.Time         `json:"eq"`
+	Ne      *time.Time         `json:"ne"`
+	Gt      *time.Time         `json:"gt"`
+	Gte     *time.Time         `json:"gte"`
+	Lt      *time.Time         `json:"lt"`
+	Lte     *time.Time         `json:"lte"`
+	Null    *bool              `json:"null"`
+	NotNull *bool              `json:"notNull"`
+	In      []*time.Time       `json:"in"`
+	NotIn   []*time.Time       `json:"notIn"`
+	Between *TimeFilterBetween `json:"between,omitempty"`
+}
+
+// GetAnd returns TimeFilterInput.And, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetAnd() []*time.Time { return v.And }
+
+// GetOr returns TimeFilterInput.Or, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetOr() []*time.Time { return v.Or }
+
+// GetNot returns TimeFilterInput.Not, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetNot() *TimeFilterInput { return v.Not }
+
+// GetEq returns TimeFilterInput.Eq, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetEq() *time.Time { return v.Eq }
+
+// GetNe returns TimeFilterInput.Ne, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetNe() *time.Time { return v.Ne }
+
+// GetGt returns TimeFilterInput.Gt, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetGt() *time.Time { return v.Gt }
+
+// GetGte returns TimeFilterInput.Gte, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetGte() *time.Time { return v.Gte }
+
+// GetLt returns TimeFilterInput.Lt, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetLt() *time.Time { return v.Lt }
+
+// GetLte returns TimeFilterInput.Lte, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetLte() *time.Time { return v.Lte }
+
+// GetNull returns TimeFilterInput.Null, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetNull() *bool { return v.Null }
+
+// GetNotNull returns TimeFilterInput.NotNull, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetNotNull() *bool { return v.NotNull }
+
+// GetIn returns TimeFilterInput.In, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetIn() []*time.Time { return v.In }
+
+// GetNotIn returns TimeFilterInput.NotIn, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetNotIn() []*time.Time { return v.NotIn }
+
+// GetBetween returns TimeFilterInput.Between, and is useful for accessing the field via an interface.
+func (v *TimeFilterInput) GetBetween() *TimeFilterBetween { return v.Between }
+
+// Filter input selection for TokenInfo
+// Can be used f.e.: by queryTokenInfo
+type TokenInfoFiltersInput struct {
+	Id              *StringFilterInput       `json:"id,omitempty"`
+	UserId          *StringFilterInput       `json:"userId,omitempty"`
+	Used            *BooleanFilterInput      `json:"used,omitempty"`
+	EncryptionLimit *IntFilterInput          `json:"encryptionLimit,omitempty"`
+	CreatedAt       *TimeFilterInput         `json:"createdAt,omitempty"`
+	UpdatedAt       *TimeFilterInput         `json:"updatedAt,omitempty"`
+	DeletedAt       *TimeFilterInput         `json:"deletedAt,omitempty"`
+	And             []*TokenInfoFiltersInput `json:"and,omitempty"`
+	Or              []*TokenInfoFiltersInput `json:"or,omitempty"`
+	Not             *TokenInfoFiltersInput   `json:"not,omitempty"`
+}
+
+// GetId returns TokenInfoFiltersInput.Id, and is useful for accessing the field via an interface.
+func (v *TokenInfoFiltersInput) GetId() *StringFilterInput { return v.Id }
+
+// GetUserId returns TokenInfoFiltersInput.UserId, and is useful for accessing the field via an interface.
+func (v *TokenInfoFiltersInput) GetUserId() *StringFilterInput { return v.UserId }
+
+// GetUsed returns TokenInfoFiltersInput.Used, and is useful for accessing the field via an interface.
+func (v *TokenInfoFiltersInput) GetUsed() *BooleanFilterInput { return v.Used }
+
+// GetEncryptionLimit returns TokenInfoFiltersInput.EncryptionLimit, and is useful for accessing the field via an interface.
+func (v *TokenInfoFiltersInput) GetEncryptionLimit() *IntFilterInput { return v.EncryptionLimit }
+
+// GetCreatedAt returns TokenInfoFiltersInput.CreatedAt, and is useful for accessing the field via an interface.
+func (v *TokenInfoFiltersInput) GetCreatedAt() *TimeFilterInput { return v.CreatedAt }
+
+// GetUpdatedAt returns TokenInfoFiltersInput.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *TokenInfoFiltersInput) GetUpdatedAt() *TimeFilterInput { return v.UpdatedAt }
+
+// GetDeletedAt returns TokenInfoFiltersInput.DeletedAt, and is useful for accessing the field via an interface.
+func (v *TokenInfoFiltersInput) GetDeletedAt() *TimeFilterInput { return v.DeletedAt }
+
+// GetAnd returns TokenInfoFiltersInput.And, and is useful for accessing the field via an interface.
+func (v *TokenInfoFiltersInput) GetAnd() []*TokenInfoFiltersInput { return v.And }
+
+// GetOr returns TokenInfoFiltersInput.Or, and is useful for accessing the field via an interface.
+func (v *TokenInfoFiltersInput) GetOr() []*TokenInfoFiltersInput { return v.Or }
+
+// GetNot returns TokenInfoFiltersInput.Not, and is useful for accessing the field via an interface.
+func (v *TokenInfoFiltersInput) GetNot() *TokenInfoFiltersInput { return v.Not }
+
+// Filter input selection for Value
+// Can be used f.e.: by queryValue
+type ValueFiltersInput struct {
+	Id        *StringFilterInput         `json:"id,omitempty"`
+	Name      *StringFilterInput         `json:"name,omitempty"`
+	VaultID   *StringFilterInput         `json:"vaultID,omitempty"`
+	Vault     *VaultFiltersInput         `json:"vault,omitempty"`
+	Value     *IdentityValueFiltersInput `json:"value,omitempty"`
+	Type      *StringFilterInput         `json:"type,omitempty"`
+	CreatedAt *TimeFilterInput           `json:"createdAt,omitempty"`
+	UpdatedAt *TimeFilterInput           `json:"updatedAt,omitempty"`
+	DeletedAt *TimeFilterInput           `json:"deletedAt,omitempty"`
+	And       []*ValueFiltersInput       `json:"and,omitempty"`
+	Or        []*ValueFiltersInput       `json:"or,omitempty"`
+	Not       *ValueFiltersInput         `json:"not,omitempty"`
+}
+
+// GetId returns ValueFiltersInput.Id, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetId() *StringFilterInput { return v.Id }
+
+// GetName returns ValueFiltersInput.Name, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetName() *StringFilterInput { return v.Name }
+
+// GetVaultID returns ValueFiltersInput.VaultID, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetVaultID() *StringFilterInput { return v.VaultID }
+
+// GetVault returns ValueFiltersInput.Vault, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetVault() *VaultFiltersInput { return v.Vault }
+
+// GetValue returns ValueFiltersInput.Value, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetValue() *IdentityValueFiltersInput { return v.Value }
+
+// GetType returns ValueFiltersInput.Type, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetType() *StringFilterInput { return v.Type }
+
+// GetCreatedAt returns ValueFiltersInput.CreatedAt, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetCreatedAt() *TimeFilterInput { return v.CreatedAt }
+
+// GetUpdatedAt returns ValueFiltersInput.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetUpdatedAt() *TimeFilterInput { return v.UpdatedAt }
+
+// GetDeletedAt returns ValueFiltersInput.DeletedAt, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetDeletedAt() *TimeFilterInput { return v.DeletedAt }
+
+// GetAnd returns ValueFiltersInput.And, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetAnd() []*ValueFiltersInput { return v.And }
+
+// GetOr returns ValueFiltersInput.Or, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetOr() []*ValueFiltersInput { return v.Or }
+
+// GetNot returns ValueFiltersInput.Not, and is useful for accessing the field via an interface.
+func (v *ValueFiltersInput) GetNot() *ValueFiltersInput { return v.Not }
+
+// Order Value by asc or desc
+type ValueOrder struct {
+	Asc  *ValueOrderable `json:"asc"`
+	Desc *ValueOrderable `json:"desc"`
+}
+
+// GetAsc returns ValueOrder.Asc, and is useful for accessing the field via an interface.
+func (v *ValueOrder) GetAsc() *ValueOrderable { return v.Asc }
+
+// GetDesc returns ValueOrder.Desc, and is useful for accessing the field via an interface.
+func (v *ValueOrder) GetDesc() *ValueOrderable { return v.Desc }
+
+// for Value a enum of all orderable entities
+// can be used f.e.: queryValue
+type ValueOrderable string
+
+const (
+	ValueOrderableId      ValueOrderable = "id"
+	ValueOrderableName    ValueOrderable = "name"
+	ValueOrderableVaultid ValueOrderable = "vaultID"
+)
+
 type ValueType string
 
 const (
@@ -85,6 +650,59 @@ const (
 	ValueTypeJson   ValueType = "JSON"
 )
 
+// Filter input selection for Vault
+// Can be used f.e.: by queryVault
+type VaultFiltersInput struct {
+	Id         *StringFilterInput     `json:"id,omitempty"`
+	Name       *StringFilterInput     `json:"name,omitempty"`
+	Identities *IdentityFiltersInput  `json:"identities,omitempty"`
+	TokenID    *StringFilterInput     `json:"tokenID,omitempty"`
+	Token      *TokenInfoFiltersInput `json:"token,omitempty"`
+	Values     *ValueFiltersInput     `json:"values,omitempty"`
+	CreatedAt  *TimeFilterInput       `json:"createdAt,omitempty"`
+	UpdatedAt  *TimeFilterInput       `json:"updatedAt,omitempty"`
+	DeletedAt  *TimeFilterInput       `json:"deletedAt,omitempty"`
+	And        []*VaultFiltersInput   `json:"and,omitempty"`
+	Or         []*VaultFiltersInput   `json:"or,omitempty"`
+	Not        *VaultFiltersInput     `json:"not,omitempty"`
+}
+
+// GetId returns VaultFiltersInput.Id, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetId() *StringFilterInput { return v.Id }
+
+// GetName returns VaultFiltersInput.Name, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetName() *StringFilterInput { return v.Name }
+
+// GetIdentities returns VaultFiltersInput.Identities, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetIdentities() *IdentityFiltersInput { return v.Identities }
+
+// GetTokenID returns VaultFiltersInput.TokenID, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetTokenID() *StringFilterInput { return v.TokenID }
+
+// GetToken returns VaultFiltersInput.Token, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetToken() *TokenInfoFiltersInput { return v.Token }
+
+// GetValues returns VaultFiltersInput.Values, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetValues() *ValueFiltersInput { return v.Values }
+
+// GetCreatedAt returns VaultFiltersInput.CreatedAt, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetCreatedAt() *TimeFilterInput { return v.CreatedAt }
+
+// GetUpdatedAt returns VaultFiltersInput.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetUpdatedAt() *TimeFilterInput { return v.UpdatedAt }
+
+// GetDeletedAt returns VaultFiltersInput.DeletedAt, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetDeletedAt() *TimeFilterInput { return v.DeletedAt }
+
+// GetAnd returns VaultFiltersInput.And, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetAnd() []*VaultFiltersInput { return v.And }
+
+// GetOr returns VaultFiltersInput.Or, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetOr() []*VaultFiltersInput { return v.Or }
+
+// GetNot returns VaultFiltersInput.Not, and is useful for accessing the field via an interface.
+func (v *VaultFiltersInput) GetNot() *VaultFiltersInput { return v.Not }
+
 // __addIdentityInput is used internally by genqlient
 type __addIdentityInput struct {
 	Name                string                 `json:"name"`
@@ -215,6 +833,14 @@ type __deleteValueInput struct {
 // GetId returns __deleteValueInput.Id, and is useful for accessing the field via an interface.
 func (v *__deleteValueInput) GetId() string { return v.Id }
 
+// __deleteValueVersionsInput is used internally by genqlient
+type __deleteValueVersionsInput struct {
+	Prefix string `json:"prefix"`
+}
+
+// GetPrefix returns __deleteValueVersionsInput.Prefix, and is useful for accessing the field via an interface.
+func (v *__deleteValueVersionsInput) GetPrefix() string { return v.Prefix }
+
 // __deleteVaultInput is used internally by genqlient
 type __deleteVaultInput struct {
 	Id string `json:"id"`
@@ -223,6 +849,22 @@ type __deleteVaultInput struct {
 // GetId returns __deleteVaultInput.Id, and is useful for accessing the field via an interface.
 func (v *__deleteVaultInput) GetId() string { return v.Id }
 
+// __exportValuesInput is used internally by genqlient
+type __exportValuesInput struct {
+	Filter *ValueFiltersInput `json:"filter,omitempty"`
+	First  int                `json:"first"`
+	Offset int                `json:"offset"`
+}
+
+// GetFilter returns __exportValuesInput.Filter, and is useful for accessing the field via an interface.
+func (v *__exportValuesInput) GetFilter() *ValueFiltersInput { return v.Filter }
+
+// GetFirst returns __exportValuesInput.First, and is useful for accessing the field via an interface.
+func (v *__exportValuesInput) GetFirst() int { return v.First }
+
+// GetOffset returns __exportValuesInput.Offset, and is useful for accessing the field via an interface.
+func (v *__exportValuesInput) GetOffset() int { return v.Offset }
+
 // __getIdentityInput is used internally by genqlient
 type __getIdentityInput struct {
 	Id string `json:"id"`
@@ -263,6 +905,46 @@ type __getVaultInput struct {
 // GetId returns __getVaultInput.Id, and is useful for accessing the field via an interface.
 func (v *__getVaultInput) GetId() string { return v.Id }
 
+// __identityCreatorVerificationsInput is used internally by genqlient
+type __identityCreatorVerificationsInput struct {
+	First  int `json:"first"`
+	Offset int `json:"offset"`
+}
+
+// GetFirst returns __identityCreatorVerificationsInput.First, and is useful for accessing the field via an interface.
+func (v *__identityCreatorVerificationsInput) GetFirst() int { return v.First }
+
+// GetOffset returns __identityCreatorVerificationsInput.Offset, and is useful for accessing the field via an interface.
+func (v *__identityCreatorVerificationsInput) GetOffset() int { return v.Offset }
+
+// __identityValuesOfIdentityInput is used internally by genqlient
+type __identityValuesOfIdentityInput struct {
+	Identity string `json:"identity"`
+}
+
+// GetIdentity returns __identityValuesOfIdentityInput.Identity, and is useful for accessing the field via an interface.
+func (v *__identityValuesOfIdentityInput) GetIdentity() string { return v.Identity }
+
+// __listValuesInput is used internally by genqlient
+type __listValuesInput struct {
+	Filter *ValueFiltersInput `json:"filter,omitempty"`
+	Order  *ValueOrder        `json:"order,omitempty"`
+	First  *int               `json:"first"`
+	Offset *int               `json:"offset"`
+}
+
+// GetFilter returns __listValuesInput.Filter, and is useful for accessing the field via an interface.
+func (v *__listValuesInput) GetFilter() *ValueFiltersInput { return v.Filter }
+
+// GetOrder returns __listValuesInput.Order, and is useful for accessing the field via an interface.
+func (v *__listValuesInput) GetOrder() *ValueOrder { return v.Order }
+
+// GetFirst returns __listValuesInput.First, and is useful for accessing the field via an interface.
+func (v *__listValuesInput) GetFirst() *int { return v.First }
+
+// GetOffset returns __listValuesInput.Offset, and is useful for accessing the field via an interface.
+func (v *__listValuesInput) GetOffset() *int { return v.Offset }
+
 // __removeIdentityValueInput is used internally by genqlient
 type __removeIdentityValueInput struct {
 	Id *string `json:"id"`
@@ -271,6 +953,32 @@ type __removeIdentityValueInput struct {
 // GetId returns __removeIdentityValueInput.Id, and is useful for accessing the field via an interface.
 func (v *__removeIdentityValueInput) GetId() *string { return v.Id }
 
+// __replaceIdentityValuesInput is used internally by genqlient
+type __replaceIdentityValuesInput struct {
+	ValueId string                `json:"valueId"`
+	Input   []*IdentityValueInput `json:"input,omitempty"`
+}
+
+// GetValueId returns __replaceIdentityValuesInput.ValueId, and is useful for accessing the field via an interface.
+func (v *__replaceIdentityValuesInput) GetValueId() string { return v.ValueId }
+
+// GetInput returns __replaceIdentityValuesInput.Input, and is useful for accessing the field via an interface.
+func (v *__replaceIdentityValuesInput) GetInput() []*IdentityValueInput { return v.Input }
+
+// __updateIdentityCreatorVerificationInput is used internally by genqlient
+type __updateIdentityCreatorVerificationInput struct {
+	Id                  string `json:"id"`
+	CreatorVerification string `json:"creatorVerification"`
+}
+
+// GetId returns __updateIdentityCreatorVerificationInput.Id, and is useful for accessing the field via an interface.
+func (v *__updateIdentityCreatorVerificationInput) GetId() string { return v.Id }
+
+// GetCreatorVerification returns __updateIdentityCreatorVerificationInput.CreatorVerification, and is useful for accessing the field via an interface.
+func (v *__updateIdentityCreatorVerificationInput) GetCreatorVerification() string {
+	return v.CreatorVerification
+}
+
 // __updateIdentityInput is used internally by genqlient
 type __updateIdentityInput struct {
 	Id   string `json:"id"`
@@ -319,6 +1027,58 @@ type __updateVaultInput struct {
 // GetName returns __updateVaultInput.Name, and is useful for accessing the field via an interface.
 func (v *__updateVaultInput) GetName() string { return v.Name }
 
+// __valueVersionsInput is used internally by genqlient
+type __valueVersionsInput struct {
+	Prefix string `json:"prefix"`
+}
+
+// GetPrefix returns __valueVersionsInput.Prefix, and is useful for accessing the field via an interface.
+func (v *__valueVersionsInput) GetPrefix() string { return v.Prefix }
+
+// __vaultIdentitiesInput is used internally by genqlient
+type __vaultIdentitiesInput struct {
+	First  int `json:"first"`
+	Offset int `json:"offset"`
+}
+
+// GetFirst returns __vaultIdentitiesInput.First, and is useful for accessing the field via an interface.
+func (v *__vaultIdentitiesInput) GetFirst() int { return v.First }
+
+// GetOffset returns __vaultIdentitiesInput.Offset, and is useful for accessing the field via an interface.
+func (v *__vaultIdentitiesInput) GetOffset() int { return v.Offset }
+
+// __watchAccessInput is used internally by genqlient
+type __watchAccessInput struct {
+	Filter *IdentityValueFiltersInput `json:"filter,omitempty"`
+	First  int                        `json:"first"`
+	Offset int                        `json:"offset"`
+}
+
+// GetFilter returns __watchAccessInput.Filter, and is useful for accessing the field via an interface.
+func (v *__watchAccessInput) GetFilter() *IdentityValueFiltersInput { return v.Filter }
+
+// GetFirst returns __watchAccessInput.First, and is useful for accessing the field via an interface.
+func (v *__watchAccessInput) GetFirst() int { return v.First }
+
+// GetOffset returns __watchAccessInput.Offset, and is useful for accessing the field via an interface.
+func (v *__watchAccessInput) GetOffset() int { return v.Offset }
+
+// __watchValuesInput is used internally by genqlient
+type __watchValuesInput struct {
+	Filter *ValueFiltersInput `json:"filter,omitempty"`
+	First  int                `json:"first"`
+	Offset int                `json:"offset"`
+}
+
+// GetFilter returns __watchValuesInput.Filter, and is useful for accessing the field via an interface.
+func (v *__watchValuesInput) GetFilter() *ValueFiltersInput { return v.Filter }
+
+// GetFirst returns __watchValuesInput.First, and is useful for accessing the field via an interface.
+func (v *__watchValuesInput) GetFirst() int { return v.First }
+
+// GetOffset returns __watchValuesInput.Offset, and is useful for accessing the field via an interface.
+func (v *__watchValuesInput) GetOffset() int { return v.Offset }
+
 // addIdentityAddIdentityAddIdentityPayload includes the requested fields of the GraphQL type AddIdentityPayload.
 // The GraphQL type's documentation follows.
 //
@@ -563,9 +1323,12 @@ func (v *allRelatedValuesWithIdentityValuesAllRelatedValuesValueValueIdentityVal
 
 // allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue includes the requested fields of the GraphQL type Value.
 type allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue struct {
-	Id    string                                                                                `json:"id"`
-	Name  string                                                                                `json:"name"`
-	Value []*allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValueValueIdentityValue `json:"value"`
+	Id        string                                                                                `json:"id"`
+	Name      string                                                                                `json:"name"`
+	Type      ValueType                                                                             `json:"type"`
+	CreatedAt *time.Time                                                                            `json:"createdAt"`
+	UpdatedAt *time.Time                                                                            `json:"updatedAt"`
+	Value     []*allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValueValueIdentityValue `json:"value"`
 }
 
 // GetId returns allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue.Id, and is useful for accessing the field via an interface.
@@ -578,6 +1341,21 @@ func (v *allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue) GetNa
 	return v.Name
 }
 
+// GetType returns allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue.Type, and is useful for accessing the field via an interface.
+func (v *allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue) GetType() ValueType {
+	return v.Type
+}
+
+// GetCreatedAt returns allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue.CreatedAt, and is useful for accessing the field via an interface.
+func (v *allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue) GetCreatedAt() *time.Time {
+	return v.CreatedAt
+}
+
+// GetUpdatedAt returns allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue) GetUpdatedAt() *time.Time {
+	return v.UpdatedAt
+}
+
 // GetValue returns allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue.Value, and is useful for accessing the field via an interface.
 func (v *allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue) GetValue() []*allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValueValueIdentityValue {
 	return v.Value
@@ -751,6 +1529,29 @@ func (v *deleteValueResponse) GetDeleteValue() *deleteValueDeleteValueDeleteValu
 	return v.DeleteValue
 }
 
+// deleteValueVersionsDeleteValueDeleteValuePayload includes the requested fields of the GraphQL type DeleteValuePayload.
+// The GraphQL type's documentation follows.
+//
+// DeleteValue result with filterable data and count of affected entries
+type deleteValueVersionsDeleteValueDeleteValuePayload struct {
+	// Count of deleted Value entities
+	Count int `json:"count"`
+}
+
+// GetCount returns deleteValueVersionsDeleteValueDeleteValuePayload.Count, and is useful for accessing the field via an interface.
+func (v *deleteValueVersionsDeleteValueDeleteValuePayload) GetCount() int { return v.Count }
+
+// deleteValueVersionsResponse is returned by deleteValueVersions on success.
+type deleteValueVersionsResponse struct {
+	// delete Value filtered by selection and delete all matched values
+	DeleteValue *deleteValueVersionsDeleteValueDeleteValuePayload `json:"deleteValue"`
+}
+
+// GetDeleteValue returns deleteValueVersionsResponse.DeleteValue, and is useful for accessing the field via an interface.
+func (v *deleteValueVersionsResponse) GetDeleteValue() *deleteValueVersionsDeleteValueDeleteValuePayload {
+	return v.DeleteValue
+}
+
 // deleteVaultDeleteVaultDeleteVaultPayload includes the requested fields of the GraphQL type DeleteVaultPayload.
 // The GraphQL type's documentation follows.
 //
@@ -774,29 +1575,111 @@ func (v *deleteVaultResponse) GetDeleteVault() *deleteVaultDeleteVaultDeleteVaul
 	return v.DeleteVault
 }
 
-// getIdentityGetIdentity includes the requested fields of the GraphQL type Identity.
-type getIdentityGetIdentity struct {
-	Id        string                               `json:"id"`
-	Name      *string                              `json:"name"`
-	PublicKey helper.Base64PublicPem               `json:"publicKey"`
-	VaultID   string                               `json:"vaultID"`
-	CreatedAt *time.Time                           `json:"createdAt"`
-	UpdatedAt *time.Time                           `json:"updatedAt"`
-	Rights    []*getIdentityGetIdentityRightsRight `json:"rights"`
+// exportValuesQueryValueValueQueryResult includes the requested fields of the GraphQL type ValueQueryResult.
+// The GraphQL type's documentation follows.
+//
+// Value result
+type exportValuesQueryValueValueQueryResult struct {
+	Data       []*exportValuesQueryValueValueQueryResultDataValue `json:"data"`
+	TotalCount int                                                `json:"totalCount"`
 }
 
-// GetId returns getIdentityGetIdentity.Id, and is useful for accessing the field via an interface.
-func (v *getIdentityGetIdentity) GetId() string { return v.Id }
+// GetData returns exportValuesQueryValueValueQueryResult.Data, and is useful for accessing the field via an interface.
+func (v *exportValuesQueryValueValueQueryResult) GetData() []*exportValuesQueryValueValueQueryResultDataValue {
+	return v.Data
+}
 
-// GetName returns getIdentityGetIdentity.Name, and is useful for accessing the field via an interface.
-func (v *getIdentityGetIdentity) GetName() *string { return v.Name }
+// GetTotalCount returns exportValuesQueryValueValueQueryResult.TotalCount, and is useful for accessing the field via an interface.
+func (v *exportValuesQueryValueValueQueryResult) GetTotalCount() int { return v.TotalCount }
 
-// GetPublicKey returns getIdentityGetIdentity.PublicKey, and is useful for accessing the field via an interface.
-func (v *getIdentityGetIdentity) GetPublicKey() helper.Base64PublicPem { return v.PublicKey }
+// exportValuesQueryValueValueQueryResultDataValue includes the requested fields of the GraphQL type Value.
+type exportValuesQueryValueValueQueryResultDataValue struct {
+	Id        string                                                               `json:"id"`
+	Name      string                                                               `json:"name"`
+	Type      ValueType                                                            `json:"type"`
+	CreatedAt *time.Time                                                           `json:"createdAt"`
+	UpdatedAt *time.Time                                                           `json:"updatedAt"`
+	Value     []*exportValuesQueryValueValueQueryResultDataValueValueIdentityValue `json:"value"`
+}
 
-// GetVaultID returns getIdentityGetIdentity.VaultID, and is useful for accessing the field via an interface.
+// GetId returns exportValuesQueryValueValueQueryResultDataValue.Id, and is useful for accessing the field via an interface.
+func (v *exportValuesQueryValueValueQueryResultDataValue) GetId() string { return v.Id }
+
+// GetName returns exportValuesQueryValueValueQueryResultDataValue.Name, and is useful for accessing the field via an interface.
+func (v *exportValuesQueryValueValueQueryResultDataValue) GetName() string { return v.Name }
+
+// GetType returns exportValuesQueryValueValueQueryResultDataValue.Type, and is useful for accessing the field via an interface.
+func (v *exportValuesQueryValueValueQueryResultDataValue) GetType() ValueType { return v.Type }
+
+// GetCreatedAt returns exportValuesQueryValueValueQueryResultDataValue.CreatedAt, and is useful for accessing the field via an interface.
+func (v *exportValuesQueryValueValueQueryResultDataValue) GetCreatedAt() *time.Time {
+	return v.CreatedAt
+}
+
+// GetUpdatedAt returns exportValuesQueryValueValueQueryResultDataValue.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *exportValuesQueryValueValueQueryResultDataValue) GetUpdatedAt() *time.Time {
+	return v.UpdatedAt
+}
+
+// GetValue returns exportValuesQueryValueValueQueryResultDataValue.Value, and is useful for accessing the field via an interface.
+func (v *exportValuesQueryValueValueQueryResultDataValue) GetValue() []*exportValuesQueryValueValueQueryResultDataValueValueIdentityValue {
+	return v.Value
+}
+
+// exportValuesQueryValueValueQueryResultDataValueValueIdentityValue includes the requested fields of the GraphQL type IdentityValue.
+type exportValuesQueryValueValueQueryResultDataValueValueIdentityValue struct {
+	IdentityID string `json:"identityID"`
+	Passframe  string `json:"passframe"`
+}
+
+// GetIdentityID returns exportValuesQueryValueValueQueryResultDataValueValueIdentityValue.IdentityID, and is useful for accessing the field via an interface.
+func (v *exportValuesQueryValueValueQueryResultDataValueValueIdentityValue) GetIdentityID() string {
+	return v.IdentityID
+}
+
+// GetPassframe returns exportValuesQueryValueValueQueryResultDataValueValueIdentityValue.Passframe, and is useful for accessing the field via an interface.
+func (v *exportValuesQueryValueValueQueryResultDataValueValueIdentityValue) GetPassframe() string {
+	return v.Passframe
+}
+
+// exportValuesResponse is returned by exportValues on success.
+type exportValuesResponse struct {
+	// return a list of  Value filterable, pageination, orderbale, groupable ...
+	QueryValue *exportValuesQueryValueValueQueryResult `json:"queryValue"`
+}
+
+// GetQueryValue returns exportValuesResponse.QueryValue, and is useful for accessing the field via an interface.
+func (v *exportValuesResponse) GetQueryValue() *exportValuesQueryValueValueQueryResult {
+	return v.QueryValue
+}
+
+// getIdentityGetIdentity includes the requested fields of the GraphQL type Identity.
+type getIdentityGetIdentity struct {
+	Id         string                               `json:"id"`
+	Name       *string                              `json:"name"`
+	PublicKey  helper.Base64PublicPem               `json:"publicKey"`
+	VaultID    string                               `json:"vaultID"`
+	IsOperator bool                                 `json:"isOperator"`
+	CreatedAt  *time.Time                           `json:"createdAt"`
+	UpdatedAt  *time.Time                           `json:"updatedAt"`
+	Rights     []*getIdentityGetIdentityRightsRight `json:"rights"`
+}
+
+// GetId returns getIdentityGetIdentity.Id, and is useful for accessing the field via an interface.
+func (v *getIdentityGetIdentity) GetId() string { return v.Id }
+
+// GetName returns getIdentityGetIdentity.Name, and is useful for accessing the field via an interface.
+func (v *getIdentityGetIdentity) GetName() *string { return v.Name }
+
+// GetPublicKey returns getIdentityGetIdentity.PublicKey, and is useful for accessing the field via an interface.
+func (v *getIdentityGetIdentity) GetPublicKey() helper.Base64PublicPem { return v.PublicKey }
+
+// GetVaultID returns getIdentityGetIdentity.VaultID, and is useful for accessing the field via an interface.
 func (v *getIdentityGetIdentity) GetVaultID() string { return v.VaultID }
 
+// GetIsOperator returns getIdentityGetIdentity.IsOperator, and is useful for accessing the field via an interface.
+func (v *getIdentityGetIdentity) GetIsOperator() bool { return v.IsOperator }
+
 // GetCreatedAt returns getIdentityGetIdentity.CreatedAt, and is useful for accessing the field via an interface.
 func (v *getIdentityGetIdentity) GetCreatedAt() *time.Time { return v.CreatedAt }
 
@@ -1065,6 +1948,164 @@ type getVaultResponse struct {
 // GetGetVault returns getVaultResponse.GetVault, and is useful for accessing the field via an interface.
 func (v *getVaultResponse) GetGetVault() *getVaultGetVault { return v.GetVault }
 
+// identityCreatorVerificationsQueryIdentityIdentityQueryResult includes the requested fields of the GraphQL type IdentityQueryResult.
+// The GraphQL type's documentation follows.
+//
+// Identity result
+type identityCreatorVerificationsQueryIdentityIdentityQueryResult struct {
+	Data       []*identityCreatorVerificationsQueryIdentityIdentityQueryResultDataIdentity `json:"data"`
+	TotalCount int                                                                         `json:"totalCount"`
+}
+
+// GetData returns identityCreatorVerificationsQueryIdentityIdentityQueryResult.Data, and is useful for accessing the field via an interface.
+func (v *identityCreatorVerificationsQueryIdentityIdentityQueryResult) GetData() []*identityCreatorVerificationsQueryIdentityIdentityQueryResultDataIdentity {
+	return v.Data
+}
+
+// GetTotalCount returns identityCreatorVerificationsQueryIdentityIdentityQueryResult.TotalCount, and is useful for accessing the field via an interface.
+func (v *identityCreatorVerificationsQueryIdentityIdentityQueryResult) GetTotalCount() int {
+	return v.TotalCount
+}
+
+// identityCreatorVerificationsQueryIdentityIdentityQueryResultDataIdentity includes the requested fields of the GraphQL type Identity.
+type identityCreatorVerificationsQueryIdentityIdentityQueryResultDataIdentity struct {
+	Id                  string `json:"id"`
+	CreatorVerification string `json:"creatorVerification"`
+}
+
+// GetId returns identityCreatorVerificationsQueryIdentityIdentityQueryResultDataIdentity.Id, and is useful for accessing the field via an interface.
+func (v *identityCreatorVerificationsQueryIdentityIdentityQueryResultDataIdentity) GetId() string {
+	return v.Id
+}
+
+// GetCreatorVerification returns identityCreatorVerificationsQueryIdentityIdentityQueryResultDataIdentity.CreatorVerification, and is useful for accessing the field via an interface.
+func (v *identityCreatorVerificationsQueryIdentityIdentityQueryResultDataIdentity) GetCreatorVerification() string {
+	return v.CreatorVerification
+}
+
+// identityCreatorVerificationsResponse is returned by identityCreatorVerifications on success.
+type identityCreatorVerificationsResponse struct {
+	// return a list of  Identity filterable, pageination, orderbale, groupable ...
+	QueryIdentity *identityCreatorVerificationsQueryIdentityIdentityQueryResult `json:"queryIdentity"`
+}
+
+// GetQueryIdentity returns identityCreatorVerificationsResponse.QueryIdentity, and is useful for accessing the field via an interface.
+func (v *identityCreatorVerificationsResponse) GetQueryIdentity() *identityCreatorVerificationsQueryIdentityIdentityQueryResult {
+	return v.QueryIdentity
+}
+
+// identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResult includes the requested fields of the GraphQL type IdentityValueQueryResult.
+// The GraphQL type's documentation follows.
+//
+// IdentityValue result
+type identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResult struct {
+	Data []*identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResultDataIdentityValue `json:"data"`
+}
+
+// GetData returns identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResult.Data, and is useful for accessing the field via an interface.
+func (v *identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResult) GetData() []*identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResultDataIdentityValue {
+	return v.Data
+}
+
+// identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResultDataIdentityValue includes the requested fields of the GraphQL type IdentityValue.
+type identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResultDataIdentityValue struct {
+	Id      string `json:"id"`
+	ValueID string `json:"valueID"`
+}
+
+// GetId returns identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResultDataIdentityValue.Id, and is useful for accessing the field via an interface.
+func (v *identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResultDataIdentityValue) GetId() string {
+	return v.Id
+}
+
+// GetValueID returns identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResultDataIdentityValue.ValueID, and is useful for accessing the field via an interface.
+func (v *identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResultDataIdentityValue) GetValueID() string {
+	return v.ValueID
+}
+
+// identityValuesOfIdentityResponse is returned by identityValuesOfIdentity on success.
+type identityValuesOfIdentityResponse struct {
+	// return a list of  IdentityValue filterable, pageination, orderbale, groupable ...
+	QueryIdentityValue *identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResult `json:"queryIdentityValue"`
+}
+
+// GetQueryIdentityValue returns identityValuesOfIdentityResponse.QueryIdentityValue, and is useful for accessing the field via an interface.
+func (v *identityValuesOfIdentityResponse) GetQueryIdentityValue() *identityValuesOfIdentityQueryIdentityValueIdentityValueQueryResult {
+	return v.QueryIdentityValue
+}
+
+// listValuesQueryValueValueQueryResult includes the requested fields of the GraphQL type ValueQueryResult.
+// The GraphQL type's documentation follows.
+//
+// Value result
+type listValuesQueryValueValueQueryResult struct {
+	Data       []*listValuesQueryValueValueQueryResultDataValue `json:"data"`
+	Count      int                                              `json:"count"`
+	TotalCount int                                              `json:"totalCount"`
+}
+
+// GetData returns listValuesQueryValueValueQueryResult.Data, and is useful for accessing the field via an interface.
+func (v *listValuesQueryValueValueQueryResult) GetData() []*listValuesQueryValueValueQueryResultDataValue {
+	return v.Data
+}
+
+// GetCount returns listValuesQueryValueValueQueryResult.Count, and is useful for accessing the field via an interface.
+func (v *listValuesQueryValueValueQueryResult) GetCount() int { return v.Count }
+
+// GetTotalCount returns listValuesQueryValueValueQueryResult.TotalCount, and is useful for accessing the field via an interface.
+func (v *listValuesQueryValueValueQueryResult) GetTotalCount() int { return v.TotalCount }
+
+// listValuesQueryValueValueQueryResultDataValue includes the requested fields of the GraphQL type Value.
+type listValuesQueryValueValueQueryResultDataValue struct {
+	Id        string                                                             `json:"id"`
+	Name      string                                                             `json:"name"`
+	Type      ValueType                                                          `json:"type"`
+	CreatedAt *time.Time                                                         `json:"createdAt"`
+	UpdatedAt *time.Time                                                         `json:"updatedAt"`
+	Value     []*listValuesQueryValueValueQueryResultDataValueValueIdentityValue `json:"value"`
+}
+
+// GetId returns listValuesQueryValueValueQueryResultDataValue.Id, and is useful for accessing the field via an interface.
+func (v *listValuesQueryValueValueQueryResultDataValue) GetId() string { return v.Id }
+
+// GetName returns listValuesQueryValueValueQueryResultDataValue.Name, and is useful for accessing the field via an interface.
+func (v *listValuesQueryValueValueQueryResultDataValue) GetName() string { return v.Name }
+
+// GetType returns listValuesQueryValueValueQueryResultDataValue.Type, and is useful for accessing the field via an interface.
+func (v *listValuesQueryValueValueQueryResultDataValue) GetType() ValueType { return v.Type }
+
+// GetCreatedAt returns listValuesQueryValueValueQueryResultDataValue.CreatedAt, and is useful for accessing the field via an interface.
+func (v *listValuesQueryValueValueQueryResultDataValue) GetCreatedAt() *time.Time { return v.CreatedAt }
+
+// GetUpdatedAt returns listValuesQueryValueValueQueryResultDataValue.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *listValuesQueryValueValueQueryResultDataValue) GetUpdatedAt() *time.Time { return v.UpdatedAt }
+
+// GetValue returns listValuesQueryValueValueQueryResultDataValue.Value, and is useful for accessing the field via an interface.
+func (v *listValuesQueryValueValueQueryResultDataValue) GetValue() []*listValuesQueryValueValueQueryResultDataValueValueIdentityValue {
+	return v.Value
+}
+
+// listValuesQueryValueValueQueryResultDataValueValueIdentityValue includes the requested fields of the GraphQL type IdentityValue.
+type listValuesQueryValueValueQueryResultDataValueValueIdentityValue struct {
+	IdentityID string `json:"identityID"`
+}
+
+// GetIdentityID returns listValuesQueryValueValueQueryResultDataValueValueIdentityValue.IdentityID, and is useful for accessing the field via an interface.
+func (v *listValuesQueryValueValueQueryResultDataValueValueIdentityValue) GetIdentityID() string {
+	return v.IdentityID
+}
+
+// listValuesResponse is returned by listValues on success.
+type listValuesResponse struct {
+	// return a list of  Value filterable, pageination, orderbale, groupable ...
+	QueryValue *listValuesQueryValueValueQueryResult `json:"queryValue"`
+}
+
+// GetQueryValue returns listValuesResponse.QueryValue, and is useful for accessing the field via an interface.
+func (v *listValuesResponse) GetQueryValue() *listValuesQueryValueValueQueryResult {
+	return v.QueryValue
+}
+
 // removeIdentityValueDeleteIdentityValueDeleteIdentityValuePayload includes the requested fields of the GraphQL type DeleteIdentityValuePayload.
 // The GraphQL type's documentation follows.
 //
@@ -1090,6 +2131,95 @@ func (v *removeIdentityValueResponse) GetDeleteIdentityValue() *removeIdentityVa
 	return v.DeleteIdentityValue
 }
 
+// replaceIdentityValuesAddIdentityValueAddIdentityValuePayload includes the requested fields of the GraphQL type AddIdentityValuePayload.
+// The GraphQL type's documentation follows.
+//
+// AddIdentityValue result with filterable data and affected rows
+type replaceIdentityValuesAddIdentityValueAddIdentityValuePayload struct {
+	Affected []*replaceIdentityValuesAddIdentityValueAddIdentityValuePayloadAffectedIdentityValue `json:"affected"`
+}
+
+// GetAffected returns replaceIdentityValuesAddIdentityValueAddIdentityValuePayload.Affected, and is useful for accessing the field via an interface.
+func (v *replaceIdentityValuesAddIdentityValueAddIdentityValuePayload) GetAffected() []*replaceIdentityValuesAddIdentityValueAddIdentityValuePayloadAffectedIdentityValue {
+	return v.Affected
+}
+
+// replaceIdentityValuesAddIdentityValueAddIdentityValuePayloadAffectedIdentityValue includes the requested fields of the GraphQL type IdentityValue.
+type replaceIdentityValuesAddIdentityValueAddIdentityValuePayloadAffectedIdentityValue struct {
+	Id string `json:"id"`
+}
+
+// GetId returns replaceIdentityValuesAddIdentityValueAddIdentityValuePayloadAffectedIdentityValue.Id, and is useful for accessing the field via an interface.
+func (v *replaceIdentityValuesAddIdentityValueAddIdentityValuePayloadAffectedIdentityValue) GetId() string {
+	return v.Id
+}
+
+// replaceIdentityValuesDeleteIdentityValueDeleteIdentityValuePayload includes the requested fields of the GraphQL type DeleteIdentityValuePayload.
+// The GraphQL type's documentation follows.
+//
+// DeleteIdentityValue result with filterable data and count of affected entries
+type replaceIdentityValuesDeleteIdentityValueDeleteIdentityValuePayload struct {
+	// Count of deleted IdentityValue entities
+	Count int `json:"count"`
+}
+
+// GetCount returns replaceIdentityValuesDeleteIdentityValueDeleteIdentityValuePayload.Count, and is useful for accessing the field via an interface.
+func (v *replaceIdentityValuesDeleteIdentityValueDeleteIdentityValuePayload) GetCount() int {
+	return v.Count
+}
+
+// replaceIdentityValuesResponse is returned by replaceIdentityValues on success.
+type replaceIdentityValuesResponse struct {
+	// delete IdentityValue filtered by selection and delete all matched values
+	DeleteIdentityValue *replaceIdentityValuesDeleteIdentityValueDeleteIdentityValuePayload `json:"deleteIdentityValue"`
+	// Add new IdentityValue
+	AddIdentityValue *replaceIdentityValuesAddIdentityValueAddIdentityValuePayload `json:"addIdentityValue"`
+}
+
+// GetDeleteIdentityValue returns replaceIdentityValuesResponse.DeleteIdentityValue, and is useful for accessing the field via an interface.
+func (v *replaceIdentityValuesResponse) GetDeleteIdentityValue() *replaceIdentityValuesDeleteIdentityValueDeleteIdentityValuePayload {
+	return v.DeleteIdentityValue
+}
+
+// GetAddIdentityValue returns replaceIdentityValuesResponse.AddIdentityValue, and is useful for accessing the field via an interface.
+func (v *replaceIdentityValuesResponse) GetAddIdentityValue() *replaceIdentityValuesAddIdentityValueAddIdentityValuePayload {
+	return v.AddIdentityValue
+}
+
+// updateIdentityCreatorVerificationResponse is returned by updateIdentityCreatorVerification on success.
+type updateIdentityCreatorVerificationResponse struct {
+	// update Identity filtered by selection and update all matched values
+	UpdateIdentity *updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayload `json:"updateIdentity"`
+}
+
+// GetUpdateIdentity returns updateIdentityCreatorVerificationResponse.UpdateIdentity, and is useful for accessing the field via an interface.
+func (v *updateIdentityCreatorVerificationResponse) GetUpdateIdentity() *updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayload {
+	return v.UpdateIdentity
+}
+
+// updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayload includes the requested fields of the GraphQL type UpdateIdentityPayload.
+// The GraphQL type's documentation follows.
+//
+// UpdateIdentity result with filterable data and affected rows
+type updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayload struct {
+	Affected []*updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayloadAffectedIdentity `json:"affected"`
+}
+
+// GetAffected returns updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayload.Affected, and is useful for accessing the field via an interface.
+func (v *updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayload) GetAffected() []*updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayloadAffectedIdentity {
+	return v.Affected
+}
+
+// updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayloadAffectedIdentity includes the requested fields of the GraphQL type Identity.
+type updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayloadAffectedIdentity struct {
+	Id string `json:"id"`
+}
+
+// GetId returns updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayloadAffectedIdentity.Id, and is useful for accessing the field via an interface.
+func (v *updateIdentityCreatorVerificationUpdateIdentityUpdateIdentityPayloadAffectedIdentity) GetId() string {
+	return v.Id
+}
+
 // updateIdentityResponse is returned by updateIdentity on success.
 type updateIdentityResponse struct {
 	// update Identity filtered by selection and update all matched values
@@ -1238,96 +2368,350 @@ func (v *updateVaultUpdateVaultUpdateVaultPayloadAffectedVault) GetUpdatedAt() *
 	return v.UpdatedAt
 }
 
-// The query or mutation executed by addIdentity.
-const addIdentity_Operation = `
-mutation addIdentity ($name: String!, $publicKey: Base64PublicPem!, $creatorVerification: String!) {
-	addIdentity(input: {name:$name,publicKey:$publicKey,creatorVerification:$creatorVerification,rights:[]}) {
-		affected {
-			id
-		}
-	}
+// valueVersionsQueryValueValueQueryResult includes the requested fields of the GraphQL type ValueQueryResult.
+// The GraphQL type's documentation follows.
+//
+// Value result
+type valueVersionsQueryValueValueQueryResult struct {
+	Data []*valueVersionsQueryValueValueQueryResultDataValue `json:"data"`
 }
-`
-
-func addIdentity(
-	ctx context.Context,
-	client graphql.Client,
-	name string,
-	publicKey helper.Base64PublicPem,
-	creatorVerification string,
-) (*addIdentityResponse, error) {
-	req := &graphql.Request{
-		OpName: "addIdentity",
-		Query:  addIdentity_Operation,
-		Variables: &__addIdentityInput{
-			Name:                name,
-			PublicKey:           publicKey,
-			CreatorVerification: creatorVerification,
-		},
-	}
-	var err error
-
-	var data addIdentityResponse
-	resp := &graphql.Response{Data: &data}
-
-	err = client.MakeRequest(
-		ctx,
-		req,
-		resp,
-	)
 
-	return &data, err
+// GetData returns valueVersionsQueryValueValueQueryResult.Data, and is useful for accessing the field via an interface.
+func (v *valueVersionsQueryValueValueQueryResult) GetData() []*valueVersionsQueryValueValueQueryResultDataValue {
+	return v.Data
 }
 
-// The query or mutation executed by addIdentityValue.
-const addIdentityValue_Operation = `
-mutation addIdentityValue ($input: [IdentityValueInput!]!) {
-	addIdentityValue(input: $input) {
-		affected {
-			id
-		}
-	}
+// valueVersionsQueryValueValueQueryResultDataValue includes the requested fields of the GraphQL type Value.
+type valueVersionsQueryValueValueQueryResultDataValue struct {
+	Id        string     `json:"id"`
+	Name      string     `json:"name"`
+	Type      ValueType  `json:"type"`
+	CreatedAt *time.Time `json:"createdAt"`
 }
-`
 
-func addIdentityValue(
-	ctx context.Context,
-	client graphql.Client,
-	input []*IdentityValueInput,
-) (*addIdentityValueResponse, error) {
-	req := &graphql.Request{
-		OpName: "addIdentityValue",
-		Query:  addIdentityValue_Operation,
-		Variables: &__addIdentityValueInput{
-			Input: input,
-		},
-	}
-	var err error
+// GetId returns valueVersionsQueryValueValueQueryResultDataValue.Id, and is useful for accessing the field via an interface.
+func (v *valueVersionsQueryValueValueQueryResultDataValue) GetId() string { return v.Id }
 
-	var data addIdentityValueResponse
-	resp := &graphql.Response{Data: &data}
+// GetName returns valueVersionsQueryValueValueQueryResultDataValue.Name, and is useful for accessing the field via an interface.
+func (v *valueVersionsQueryValueValueQueryResultDataValue) GetName() string { return v.Name }
 
-	err = client.MakeRequest(
-		ctx,
-		req,
-		resp,
-	)
+// GetType returns valueVersionsQueryValueValueQueryResultDataValue.Type, and is useful for accessing the field via an interface.
+func (v *valueVersionsQueryValueValueQueryResultDataValue) GetType() ValueType { return v.Type }
 
-	return &data, err
+// GetCreatedAt returns valueVersionsQueryValueValueQueryResultDataValue.CreatedAt, and is useful for accessing the field via an interface.
+func (v *valueVersionsQueryValueValueQueryResultDataValue) GetCreatedAt() *time.Time {
+	return v.CreatedAt
 }
 
-// The query or mutation executed by addRight.
-const addRight_Operation = `
-mutation addRight ($input: [RightInput!]!) {
-	addRight(input: $input) {
-		affected {
-			id
-		}
-	}
+// valueVersionsResponse is returned by valueVersions on success.
+type valueVersionsResponse struct {
+	// return a list of  Value filterable, pageination, orderbale, groupable ...
+	QueryValue *valueVersionsQueryValueValueQueryResult `json:"queryValue"`
 }
-`
 
-func addRight(
+// GetQueryValue returns valueVersionsResponse.QueryValue, and is useful for accessing the field via an interface.
+func (v *valueVersionsResponse) GetQueryValue() *valueVersionsQueryValueValueQueryResult {
+	return v.QueryValue
+}
+
+// vaultIdentitiesQueryIdentityIdentityQueryResult includes the requested fields of the GraphQL type IdentityQueryResult.
+// The GraphQL type's documentation follows.
+//
+// Identity result
+type vaultIdentitiesQueryIdentityIdentityQueryResult struct {
+	Data       []*vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity `json:"data"`
+	TotalCount int                                                            `json:"totalCount"`
+}
+
+// GetData returns vaultIdentitiesQueryIdentityIdentityQueryResult.Data, and is useful for accessing the field via an interface.
+func (v *vaultIdentitiesQueryIdentityIdentityQueryResult) GetData() []*vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity {
+	return v.Data
+}
+
+// GetTotalCount returns vaultIdentitiesQueryIdentityIdentityQueryResult.TotalCount, and is useful for accessing the field via an interface.
+func (v *vaultIdentitiesQueryIdentityIdentityQueryResult) GetTotalCount() int { return v.TotalCount }
+
+// vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity includes the requested fields of the GraphQL type Identity.
+type vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity struct {
+	Id                  string                 `json:"id"`
+	Name                *string                `json:"name"`
+	PublicKey           helper.Base64PublicPem `json:"publicKey"`
+	CreatorVerification string                 `json:"creatorVerification"`
+	IsOperator          bool                   `json:"isOperator"`
+}
+
+// GetId returns vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity.Id, and is useful for accessing the field via an interface.
+func (v *vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity) GetId() string { return v.Id }
+
+// GetName returns vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity.Name, and is useful for accessing the field via an interface.
+func (v *vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity) GetName() *string {
+	return v.Name
+}
+
+// GetPublicKey returns vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity.PublicKey, and is useful for accessing the field via an interface.
+func (v *vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity) GetPublicKey() helper.Base64PublicPem {
+	return v.PublicKey
+}
+
+// GetCreatorVerification returns vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity.CreatorVerification, and is useful for accessing the field via an interface.
+func (v *vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity) GetCreatorVerification() string {
+	return v.CreatorVerification
+}
+
+// GetIsOperator returns vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity.IsOperator, and is useful for accessing the field via an interface.
+func (v *vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity) GetIsOperator() bool {
+	return v.IsOperator
+}
+
+// vaultIdentitiesResponse is returned by vaultIdentities on success.
+type vaultIdentitiesResponse struct {
+	// return a list of  Identity filterable, pageination, orderbale, groupable ...
+	QueryIdentity *vaultIdentitiesQueryIdentityIdentityQueryResult `json:"queryIdentity"`
+}
+
+// GetQueryIdentity returns vaultIdentitiesResponse.QueryIdentity, and is useful for accessing the field via an interface.
+func (v *vaultIdentitiesResponse) GetQueryIdentity() *vaultIdentitiesQueryIdentityIdentityQueryResult {
+	return v.QueryIdentity
+}
+
+// watchAccessQueryIdentityValueIdentityValueQueryResult includes the requested fields of the GraphQL type IdentityValueQueryResult.
+// The GraphQL type's documentation follows.
+//
+// IdentityValue result
+type watchAccessQueryIdentityValueIdentityValueQueryResult struct {
+	Data       []*watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue `json:"data"`
+	TotalCount int                                                                       `json:"totalCount"`
+}
+
+// GetData returns watchAccessQueryIdentityValueIdentityValueQueryResult.Data, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResult) GetData() []*watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue {
+	return v.Data
+}
+
+// GetTotalCount returns watchAccessQueryIdentityValueIdentityValueQueryResult.TotalCount, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResult) GetTotalCount() int {
+	return v.TotalCount
+}
+
+// watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue includes the requested fields of the GraphQL type IdentityValue.
+type watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue struct {
+	Id        string                                                                       `json:"id"`
+	ValueID   string                                                                       `json:"valueID"`
+	UpdatedAt *time.Time                                                                   `json:"updatedAt"`
+	Value     *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue `json:"value"`
+}
+
+// GetId returns watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue.Id, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue) GetId() string {
+	return v.Id
+}
+
+// GetValueID returns watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue.ValueID, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue) GetValueID() string {
+	return v.ValueID
+}
+
+// GetUpdatedAt returns watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue) GetUpdatedAt() *time.Time {
+	return v.UpdatedAt
+}
+
+// GetValue returns watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue.Value, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue) GetValue() *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue {
+	return v.Value
+}
+
+// watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue includes the requested fields of the GraphQL type Value.
+type watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue struct {
+	Id        string     `json:"id"`
+	Name      string     `json:"name"`
+	Type      ValueType  `json:"type"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+// GetId returns watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue.Id, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue) GetId() string {
+	return v.Id
+}
+
+// GetName returns watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue.Name, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue) GetName() string {
+	return v.Name
+}
+
+// GetType returns watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue.Type, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue) GetType() ValueType {
+	return v.Type
+}
+
+// GetCreatedAt returns watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue.CreatedAt, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue) GetCreatedAt() *time.Time {
+	return v.CreatedAt
+}
+
+// GetUpdatedAt returns watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValueValue) GetUpdatedAt() *time.Time {
+	return v.UpdatedAt
+}
+
+// watchAccessResponse is returned by watchAccess on success.
+type watchAccessResponse struct {
+	// return a list of  IdentityValue filterable, pageination, orderbale, groupable ...
+	QueryIdentityValue *watchAccessQueryIdentityValueIdentityValueQueryResult `json:"queryIdentityValue"`
+}
+
+// GetQueryIdentityValue returns watchAccessResponse.QueryIdentityValue, and is useful for accessing the field via an interface.
+func (v *watchAccessResponse) GetQueryIdentityValue() *watchAccessQueryIdentityValueIdentityValueQueryResult {
+	return v.QueryIdentityValue
+}
+
+// watchValuesQueryValueValueQueryResult includes the requested fields of the GraphQL type ValueQueryResult.
+// The GraphQL type's documentation follows.
+//
+// Value result
+type watchValuesQueryValueValueQueryResult struct {
+	Data       []*watchValuesQueryValueValueQueryResultDataValue `json:"data"`
+	TotalCount int                                               `json:"totalCount"`
+}
+
+// GetData returns watchValuesQueryValueValueQueryResult.Data, and is useful for accessing the field via an interface.
+func (v *watchValuesQueryValueValueQueryResult) GetData() []*watchValuesQueryValueValueQueryResultDataValue {
+	return v.Data
+}
+
+// GetTotalCount returns watchValuesQueryValueValueQueryResult.TotalCount, and is useful for accessing the field via an interface.
+func (v *watchValuesQueryValueValueQueryResult) GetTotalCount() int { return v.TotalCount }
+
+// watchValuesQueryValueValueQueryResultDataValue includes the requested fields of the GraphQL type Value.
+type watchValuesQueryValueValueQueryResultDataValue struct {
+	Id        string     `json:"id"`
+	Name      string     `json:"name"`
+	Type      ValueType  `json:"type"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+// GetId returns watchValuesQueryValueValueQueryResultDataValue.Id, and is useful for accessing the field via an interface.
+func (v *watchValuesQueryValueValueQueryResultDataValue) GetId() string { return v.Id }
+
+// GetName returns watchValuesQueryValueValueQueryResultDataValue.Name, and is useful for accessing the field via an interface.
+func (v *watchValuesQueryValueValueQueryResultDataValue) GetName() string { return v.Name }
+
+// GetType returns watchValuesQueryValueValueQueryResultDataValue.Type, and is useful for accessing the field via an interface.
+func (v *watchValuesQueryValueValueQueryResultDataValue) GetType() ValueType { return v.Type }
+
+// GetCreatedAt returns watchValuesQueryValueValueQueryResultDataValue.CreatedAt, and is useful for accessing the field via an interface.
+func (v *watchValuesQueryValueValueQueryResultDataValue) GetCreatedAt() *time.Time {
+	return v.CreatedAt
+}
+
+// GetUpdatedAt returns watchValuesQueryValueValueQueryResultDataValue.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *watchValuesQueryValueValueQueryResultDataValue) GetUpdatedAt() *time.Time {
+	return v.UpdatedAt
+}
+
+// watchValuesResponse is returned by watchValues on success.
+type watchValuesResponse struct {
+	// return a list of  Value filterable, pageination, orderbale, groupable ...
+	QueryValue *watchValuesQueryValueValueQueryResult `json:"queryValue"`
+}
+
+// GetQueryValue returns watchValuesResponse.QueryValue, and is useful for accessing the field via an interface.
+func (v *watchValuesResponse) GetQueryValue() *watchValuesQueryValueValueQueryResult {
+	return v.QueryValue
+}
+
+// The query or mutation executed by addIdentity.
+const addIdentity_Operation = `
+mutation addIdentity ($name: String!, $publicKey: Base64PublicPem!, $creatorVerification: String!) {
+	addIdentity(input: {name:$name,publicKey:$publicKey,creatorVerification:$creatorVerification,rights:[]}) {
+		affected {
+			id
+		}
+	}
+}
+`
+
+func addIdentity(
+	ctx context.Context,
+	client graphql.Client,
+	name string,
+	publicKey helper.Base64PublicPem,
+	creatorVerification string,
+) (*addIdentityResponse, error) {
+	req := &graphql.Request{
+		OpName: "addIdentity",
+		Query:  addIdentity_Operation,
+		Variables: &__addIdentityInput{
+			Name:                name,
+			PublicKey:           publicKey,
+			CreatorVerification: creatorVerification,
+		},
+	}
+	var err error
+
+	var data addIdentityResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by addIdentityValue.
+const addIdentityValue_Operation = `
+mutation addIdentityValue ($input: [IdentityValueInput!]!) {
+	addIdentityValue(input: $input) {
+		affected {
+			id
+		}
+	}
+}
+`
+
+func addIdentityValue(
+	ctx context.Context,
+	client graphql.Client,
+	input []*IdentityValueInput,
+) (*addIdentityValueResponse, error) {
+	req := &graphql.Request{
+		OpName: "addIdentityValue",
+		Query:  addIdentityValue_Operation,
+		Variables: &__addIdentityValueInput{
+			Input: input,
+		},
+	}
+	var err error
+
+	var data addIdentityValueResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by addRight.
+const addRight_Operation = `
+mutation addRight ($input: [RightInput!]!) {
+	addRight(input: $input) {
+		affected {
+			id
+		}
+	}
+}
+`
+
+func addRight(
 	ctx context.Context,
 	client graphql.Client,
 	input []*RightInput,
@@ -1512,6 +2896,9 @@ query allRelatedValuesWithIdentityValuesAndSecret ($identity: String!) {
 	allRelatedValues(identityId: $identity) {
 		id
 		name
+		type
+		createdAt
+		updatedAt
 		value {
 			id
 			identityID
@@ -1761,30 +3148,30 @@ func deleteValue(
 	return &data, err
 }
 
-// The query or mutation executed by deleteVault.
-const deleteVault_Operation = `
-mutation deleteVault ($id: String!) {
-	deleteVault(filter: {id:{eq:$id}}) {
+// The query or mutation executed by deleteValueVersions.
+const deleteValueVersions_Operation = `
+mutation deleteValueVersions ($prefix: String!) {
+	deleteValue(filter: {name:{startsWith:$prefix}}) {
 		count
 	}
 }
 `
 
-func deleteVault(
+func deleteValueVersions(
 	ctx context.Context,
 	client graphql.Client,
-	id string,
-) (*deleteVaultResponse, error) {
+	prefix string,
+) (*deleteValueVersionsResponse, error) {
 	req := &graphql.Request{
-		OpName: "deleteVault",
-		Query:  deleteVault_Operation,
-		Variables: &__deleteVaultInput{
-			Id: id,
+		OpName: "deleteValueVersions",
+		Query:  deleteValueVersions_Operation,
+		Variables: &__deleteValueVersionsInput{
+			Prefix: prefix,
 		},
 	}
 	var err error
 
-	var data deleteVaultResponse
+	var data deleteValueVersionsResponse
 	resp := &graphql.Response{Data: &data}
 
 	err = client.MakeRequest(
@@ -1796,41 +3183,30 @@ func deleteVault(
 	return &data, err
 }
 
-// The query or mutation executed by getIdentity.
-const getIdentity_Operation = `
-query getIdentity ($id: String!) {
-	getIdentity(id: $id) {
-		id
-		name
-		publicKey
-		vaultID
-		createdAt
-		updatedAt
-		rights {
-			id
-			right
-			target
-			rightValuePattern
-		}
+// The query or mutation executed by deleteVault.
+const deleteVault_Operation = `
+mutation deleteVault ($id: String!) {
+	deleteVault(filter: {id:{eq:$id}}) {
+		count
 	}
 }
 `
 
-func getIdentity(
+func deleteVault(
 	ctx context.Context,
 	client graphql.Client,
 	id string,
-) (*getIdentityResponse, error) {
+) (*deleteVaultResponse, error) {
 	req := &graphql.Request{
-		OpName: "getIdentity",
-		Query:  getIdentity_Operation,
-		Variables: &__getIdentityInput{
+		OpName: "deleteVault",
+		Query:  deleteVault_Operation,
+		Variables: &__deleteVaultInput{
 			Id: id,
 		},
 	}
 	var err error
 
-	var data getIdentityResponse
+	var data deleteVaultResponse
 	resp := &graphql.Response{Data: &data}
 
 	err = client.MakeRequest(
@@ -1842,12 +3218,109 @@ func getIdentity(
 	return &data, err
 }
 
-// The query or mutation executed by getRelatedIdenties.
-const getRelatedIdenties_Operation = `
-query getRelatedIdenties ($value: String!) {
-	identitiesWithValueAccess(forValue: $value) {
-		id
-		name
+// The query or mutation executed by exportValues.
+const exportValues_Operation = `
+query exportValues ($filter: ValueFiltersInput!, $first: Int!, $offset: Int!) {
+	queryValue(filter: $filter, order: {asc:id}, first: $first, offset: $offset) {
+		data {
+			id
+			name
+			type
+			createdAt
+			updatedAt
+			value {
+				identityID
+				passframe
+			}
+		}
+		totalCount
+	}
+}
+`
+
+func exportValues(
+	ctx context.Context,
+	client graphql.Client,
+	filter *ValueFiltersInput,
+	first int,
+	offset int,
+) (*exportValuesResponse, error) {
+	req := &graphql.Request{
+		OpName: "exportValues",
+		Query:  exportValues_Operation,
+		Variables: &__exportValuesInput{
+			Filter: filter,
+			First:  first,
+			Offset: offset,
+		},
+	}
+	var err error
+
+	var data exportValuesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by getIdentity.
+const getIdentity_Operation = `
+query getIdentity ($id: String!) {
+	getIdentity(id: $id) {
+		id
+		name
+		publicKey
+		vaultID
+		isOperator
+		createdAt
+		updatedAt
+		rights {
+			id
+			right
+			target
+			rightValuePattern
+		}
+	}
+}
+`
+
+func getIdentity(
+	ctx context.Context,
+	client graphql.Client,
+	id string,
+) (*getIdentityResponse, error) {
+	req := &graphql.Request{
+		OpName: "getIdentity",
+		Query:  getIdentity_Operation,
+		Variables: &__getIdentityInput{
+			Id: id,
+		},
+	}
+	var err error
+
+	var data getIdentityResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by getRelatedIdenties.
+const getRelatedIdenties_Operation = `
+query getRelatedIdenties ($value: String!) {
+	identitiesWithValueAccess(forValue: $value) {
+		id
+		name
 		publicKey
 		creatorVerification
 		isOperator
@@ -2014,6 +3487,137 @@ func getVault(
 	return &data, err
 }
 
+// The query or mutation executed by identityCreatorVerifications.
+const identityCreatorVerifications_Operation = `
+query identityCreatorVerifications ($first: Int!, $offset: Int!) {
+	queryIdentity(order: {asc:id}, first: $first, offset: $offset) {
+		data {
+			id
+			creatorVerification
+		}
+		totalCount
+	}
+}
+`
+
+func identityCreatorVerifications(
+	ctx context.Context,
+	client graphql.Client,
+	first int,
+	offset int,
+) (*identityCreatorVerificationsResponse, error) {
+	req := &graphql.Request{
+		OpName: "identityCreatorVerifications",
+		Query:  identityCreatorVerifications_Operation,
+		Variables: &__identityCreatorVerificationsInput{
+			First:  first,
+			Offset: offset,
+		},
+	}
+	var err error
+
+	var data identityCreatorVerificationsResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by identityValuesOfIdentity.
+const identityValuesOfIdentity_Operation = `
+query identityValuesOfIdentity ($identity: String!) {
+	queryIdentityValue(filter: {identityID:{eq:$identity}}) {
+		data {
+			id
+			valueID
+		}
+	}
+}
+`
+
+func identityValuesOfIdentity(
+	ctx context.Context,
+	client graphql.Client,
+	identity string,
+) (*identityValuesOfIdentityResponse, error) {
+	req := &graphql.Request{
+		OpName: "identityValuesOfIdentity",
+		Query:  identityValuesOfIdentity_Operation,
+		Variables: &__identityValuesOfIdentityInput{
+			Identity: identity,
+		},
+	}
+	var err error
+
+	var data identityValuesOfIdentityResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by listValues.
+const listValues_Operation = `
+query listValues ($filter: ValueFiltersInput, $order: ValueOrder, $first: Int, $offset: Int) {
+	queryValue(filter: $filter, order: $order, first: $first, offset: $offset) {
+		data {
+			id
+			name
+			type
+			createdAt
+			updatedAt
+			value {
+				identityID
+			}
+		}
+		count
+		totalCount
+	}
+}
+`
+
+func listValues(
+	ctx context.Context,
+	client graphql.Client,
+	filter *ValueFiltersInput,
+	order *ValueOrder,
+	first *int,
+	offset *int,
+) (*listValuesResponse, error) {
+	req := &graphql.Request{
+		OpName: "listValues",
+		Query:  listValues_Operation,
+		Variables: &__listValuesInput{
+			Filter: filter,
+			Order:  order,
+			First:  first,
+			Offset: offset,
+		},
+	}
+	var err error
+
+	var data listValuesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 // The query or mutation executed by removeIdentityValue.
 const removeIdentityValue_Operation = `
 mutation removeIdentityValue ($id: ID) {
@@ -2049,6 +3653,48 @@ func removeIdentityValue(
 	return &data, err
 }
 
+// The query or mutation executed by replaceIdentityValues.
+const replaceIdentityValues_Operation = `
+mutation replaceIdentityValues ($valueId: String!, $input: [IdentityValueInput!]!) {
+	deleteIdentityValue(filter: {valueID:{eq:$valueId}}) {
+		count
+	}
+	addIdentityValue(input: $input) {
+		affected {
+			id
+		}
+	}
+}
+`
+
+func replaceIdentityValues(
+	ctx context.Context,
+	client graphql.Client,
+	valueId string,
+	input []*IdentityValueInput,
+) (*replaceIdentityValuesResponse, error) {
+	req := &graphql.Request{
+		OpName: "replaceIdentityValues",
+		Query:  replaceIdentityValues_Operation,
+		Variables: &__replaceIdentityValuesInput{
+			ValueId: valueId,
+			Input:   input,
+		},
+	}
+	var err error
+
+	var data replaceIdentityValuesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 // The query or mutation executed by updateIdentity.
 const updateIdentity_Operation = `
 mutation updateIdentity ($id: String!, $name: String!) {
@@ -2089,6 +3735,45 @@ func updateIdentity(
 	return &data, err
 }
 
+// The query or mutation executed by updateIdentityCreatorVerification.
+const updateIdentityCreatorVerification_Operation = `
+mutation updateIdentityCreatorVerification ($id: String!, $creatorVerification: String!) {
+	updateIdentity(input: {set:{creatorVerification:$creatorVerification},filter:{id:{eq:$id}}}) {
+		affected {
+			id
+		}
+	}
+}
+`
+
+func updateIdentityCreatorVerification(
+	ctx context.Context,
+	client graphql.Client,
+	id string,
+	creatorVerification string,
+) (*updateIdentityCreatorVerificationResponse, error) {
+	req := &graphql.Request{
+		OpName: "updateIdentityCreatorVerification",
+		Query:  updateIdentityCreatorVerification_Operation,
+		Variables: &__updateIdentityCreatorVerificationInput{
+			Id:                  id,
+			CreatorVerification: creatorVerification,
+		},
+	}
+	var err error
+
+	var data updateIdentityCreatorVerificationResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
 // The query or mutation executed by updateIdentityValue.
 const updateIdentityValue_Operation = `
 mutation updateIdentityValue ($id: ID!, $input: IdentityValuePatch!) {
@@ -2207,3 +3892,184 @@ func updateVault(
 
 	return &data, err
 }
+
+// The query or mutation executed by valueVersions.
+const valueVersions_Operation = `
+query valueVersions ($prefix: String!) {
+	queryValue(filter: {name:{startsWith:$prefix}}) {
+		data {
+			id
+			name
+			type
+			createdAt
+		}
+	}
+}
+`
+
+func valueVersions(
+	ctx context.Context,
+	client graphql.Client,
+	prefix string,
+) (*valueVersionsResponse, error) {
+	req := &graphql.Request{
+		OpName: "valueVersions",
+		Query:  valueVersions_Operation,
+		Variables: &__valueVersionsInput{
+			Prefix: prefix,
+		},
+	}
+	var err error
+
+	var data valueVersionsResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by vaultIdentities.
+const vaultIdentities_Operation = `
+query vaultIdentities ($first: Int!, $offset: Int!) {
+	queryIdentity(order: {asc:id}, first: $first, offset: $offset) {
+		data {
+			id
+			name
+			publicKey
+			creatorVerification
+			isOperator
+		}
+		totalCount
+	}
+}
+`
+
+func vaultIdentities(
+	ctx context.Context,
+	client graphql.Client,
+	first int,
+	offset int,
+) (*vaultIdentitiesResponse, error) {
+	req := &graphql.Request{
+		OpName: "vaultIdentities",
+		Query:  vaultIdentities_Operation,
+		Variables: &__vaultIdentitiesInput{
+			First:  first,
+			Offset: offset,
+		},
+	}
+	var err error
+
+	var data vaultIdentitiesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by watchAccess.
+const watchAccess_Operation = `
+query watchAccess ($filter: IdentityValueFiltersInput!, $first: Int!, $offset: Int!) {
+	queryIdentityValue(filter: $filter, order: {asc:id}, first: $first, offset: $offset) {
+		data {
+			id
+			valueID
+			updatedAt
+			value {
+				id
+				name
+				type
+				createdAt
+				updatedAt
+			}
+		}
+		totalCount
+	}
+}
+`
+
+func watchAccess(
+	ctx context.Context,
+	client graphql.Client,
+	filter *IdentityValueFiltersInput,
+	first int,
+	offset int,
+) (*watchAccessResponse, error) {
+	req := &graphql.Request{
+		OpName: "watchAccess",
+		Query:  watchAccess_Operation,
+		Variables: &__watchAccessInput{
+			Filter: filter,
+			First:  first,
+			Offset: offset,
+		},
+	}
+	var err error
+
+	var data watchAccessResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
+
+// The query or mutation executed by watchValues.
+const watchValues_Operation = `
+query watchValues ($filter: ValueFiltersInput!, $first: Int!, $offset: Int!) {
+	queryValue(filter: $filter, order: {asc:id}, first: $first, offset: $offset) {
+		data {
+			id
+			name
+			type
+			createdAt
+			updatedAt
+		}
+		totalCount
+	}
+}
+`
+
+func watchValues(
+	ctx context.Context,
+	client graphql.Client,
+	filter *ValueFiltersInput,
+	first int,
+	offset int,
+) (*watchValuesResponse, error) {
+	req := &graphql.Request{
+		OpName: "watchValues",
+		Query:  watchValues_Operation,
+		Variables: &__watchValuesInput{
+			Filter: filter,
+			First:  first,
+			Offset: offset,
+		},
+	}
+	var err error
+
+	var data watchValuesResponse
+	resp := &graphql.Response{Data: &data}
+
+	err = client.MakeRequest(
+		ctx,
+		req,
+		resp,
+	)
+
+	return &data, err
+}
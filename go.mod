@@ -6,13 +6,15 @@ toolchain go1.22.1
 
 require github.com/Khan/genqlient v0.6.0
 
-require github.com/cryptvault-cloud/helper v0.0.13
+require (
+	github.com/cryptvault-cloud/helper v0.0.13
+	github.com/vektah/gqlparser/v2 v2.5.16
+	golang.org/x/crypto v0.25.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
-	github.com/vektah/gqlparser/v2 v2.5.16 // indirect
-	golang.org/x/crypto v0.25.0 // indirect
 	golang.org/x/sys v0.22.0 // indirect
 )
 
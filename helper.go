@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/cryptvault-cloud/helper"
 )
@@ -17,14 +18,47 @@ var ValuePatternRegex *regexp.Regexp
 
 var ValuesPatternRegex *regexp.Regexp
 
+// valueNamePatternRegex matches patterns for value names like VALUES.a.*.c or VALUES.a.>
+var valueNamePatternRegex = regexp.MustCompile(`^VALUES(\.([\w\-]+|\*))*\.([\w\-]+|\*|>)$`)
+
 func init() {
 	ValuePatternRegex = regexp.MustCompile(helper.ValuePatternRegexStr)
 	ValuesPatternRegex = regexp.MustCompile(helper.ValuesPatternRegexStr)
 }
 
+// checkValueNamePattern returns an error wrapping ErrInvalidRightPattern if pattern is no valid pattern for value names
+func checkValueNamePattern(pattern string) error {
+	if !valueNamePatternRegex.MatchString(pattern) {
+		return fmt.Errorf("%w: %s does not match %s", ErrInvalidRightPattern, pattern, valueNamePatternRegex)
+	}
+	return nil
+}
+
+// matchValueName matches the name of a value against a pattern, * matches exactly one token,
+// > matches one or more tokens at the end. Names are matched with the VALUES. prefix like rights are.
+func matchValueName(pattern, name string) bool {
+	if !strings.HasPrefix(name, helper.ValuesPrefix) {
+		name = helper.ValuesPrefix + name
+	}
+	p := strings.Split(pattern, ".")
+	t := strings.Split(name, ".")
+	for i, v := range p {
+		if v == ">" {
+			return len(t) > i
+		}
+		if i >= len(t) {
+			return false
+		}
+		if v != "*" && v != t[i] {
+			return false
+		}
+	}
+	return len(p) == len(t)
+}
+
 func GetRightDescriptionByString(valuePattern string) ([]RightDescription, error) {
 	if !ValuePatternRegex.MatchString(valuePattern) {
-		return nil, fmt.Errorf("valuePattern does not match %s", helper.ValuePatternRegexStr)
+		return nil, fmt.Errorf("%w: valuePattern does not match %s", ErrInvalidRightPattern, helper.ValuePatternRegexStr)
 	}
 	var direction, target, pattern []byte
 
@@ -35,7 +69,7 @@ func GetRightDescriptionByString(valuePattern string) ([]RightDescription, error
 	}
 
 	if len(string(direction)) > 3 {
-		return nil, fmt.Errorf("direction can max be rwd")
+		return nil, fmt.Errorf("%w: direction can max be rwd", ErrInvalidRightPattern)
 	}
 	var result []RightDescription
 
@@ -67,3 +101,26 @@ func GetRightDescriptionByString(valuePattern string) ([]RightDescription, error
 	}
 	return result, nil
 }
+
+// valueNamePrefix returns the literal part of pattern up to its first wildcard, f.e.: VALUES.a. for VALUES.a.*.b.
+// A pattern without wildcards is returned as it is.
+func valueNamePrefix(pattern string) string {
+	segments := strings.Split(pattern, ".")
+	for i, segment := range segments {
+		if segment == "*" || segment == ">" {
+			return strings.Join(segments[:i], ".") + "."
+		}
+	}
+	return pattern
+}
+
+// valueNamePrefixFilter filters values whose name starts with the literal prefix of pattern, see valueNamePrefix.
+// Names may be stored without the VALUES. prefix, see matchValueName, so both forms are matched.
+func valueNamePrefixFilter(pattern string) *ValueFiltersInput {
+	prefix := valueNamePrefix(pattern)
+	unprefixed := strings.TrimPrefix(prefix, helper.ValuesPrefix)
+	return &ValueFiltersInput{Or: []*ValueFiltersInput{
+		{Name: &StringFilterInput{StartsWith: &prefix}},
+		{Name: &StringFilterInput{StartsWith: &unprefixed}},
+	}}
+}
@@ -110,3 +110,44 @@ func TestGetRightDescriptionByString(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchValueName(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "VALUES.a.>", name: "VALUES.a.b.c", want: true},
+		{pattern: "VALUES.a.>", name: "VALUES.a", want: false},
+		{pattern: "VALUES.a.*", name: "VALUES.a.b", want: true},
+		{pattern: "VALUES.a.*", name: "VALUES.a.b.c", want: false},
+		{pattern: "VALUES.*.c", name: "VALUES.b.c", want: true},
+		{pattern: "VALUES.a", name: "a", want: true},
+	}
+	for _, tt := range tests {
+		if err := checkValueNamePattern(tt.pattern); err != nil {
+			t.Errorf("checkValueNamePattern(%s) error = %v", tt.pattern, err)
+		}
+		if got := matchValueName(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchValueName(%s, %s) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+	for _, pattern := range []string{"VALUES", "VALUES.>.a", "IDENTITY.>", "VALUES..a"} {
+		if err := checkValueNamePattern(pattern); err == nil {
+			t.Errorf("checkValueNamePattern(%s) error = nil", pattern)
+		}
+	}
+}
+
+func TestValueNamePrefix(t *testing.T) {
+	for pattern, want := range map[string]string{
+		"VALUES.a.>":   "VALUES.a.",
+		"VALUES.a.*.b": "VALUES.a.",
+		"VALUES.*":     "VALUES.",
+		"VALUES.a.b":   "VALUES.a.b",
+	} {
+		if got := valueNamePrefix(pattern); got != want {
+			t.Errorf("valueNamePrefix(%s) = %s, want %s", pattern, got, want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 
 	"github.com/cryptvault-cloud/helper"
 )
@@ -12,11 +13,19 @@ var _ IdentityHandler = (*ProtectedApi)(nil)
 
 type IdentityHandler interface {
 	AddIdentity(name string, publicKey *ecdsa.PublicKey, rights []*RightInput) (*AddIdentityResponse, error)
-	UpdateIdentity(id string, name string, rights []*RightInput) (*AddIdentityResponse, error)
+	AddIdentityContext(ctx context.Context, name string, publicKey *ecdsa.PublicKey, rights []*RightInput) (*AddIdentityResponse, error)
+	UpdateIdentity(id string, name string, rights []*RightInput) (*UpdateIdentityResponse, error)
+	UpdateIdentityContext(ctx context.Context, id string, name string, rights []*RightInput) (*UpdateIdentityResponse, error)
 	GetIdentity(id string) (*getIdentityGetIdentity, error)
+	GetIdentityContext(ctx context.Context, id string) (*getIdentityGetIdentity, error)
 	CreateIdentity(name string, rights []*RightInput) (*CreateIdentityResponse, error)
+	CreateIdentityContext(ctx context.Context, name string, rights []*RightInput) (*CreateIdentityResponse, error)
 	DeleteIdentity(tokenId string) error
+	DeleteIdentityContext(ctx context.Context, tokenId string) error
 	GetAllIdentities() (*allIdentitiesResponse, error)
+	GetAllIdentitiesContext(ctx context.Context) (*allIdentitiesResponse, error)
+	RotateIdentityKey(identityId string, privateKey *ecdsa.PrivateKey) (*CreateIdentityResponse, error)
+	RotateIdentityKeyContext(ctx context.Context, identityId string, privateKey *ecdsa.PrivateKey) (*CreateIdentityResponse, error)
 }
 
 type AddIdentityResponse struct {
@@ -30,7 +39,19 @@ type CreateIdentityResponse struct {
 	PrivateKey *ecdsa.PrivateKey
 }
 
+// UpdateIdentityResponse reports which rights UpdateIdentity added, kept and removed
+type UpdateIdentityResponse struct {
+	*AddIdentityResponse
+	AddedRights   []RightDescription
+	KeptRights    []RightDescription
+	RemovedRights []RightDescription
+}
+
 func (a *ProtectedApi) AddIdentity(name string, publicKey *ecdsa.PublicKey, rights []*RightInput) (*AddIdentityResponse, error) {
+	return a.AddIdentityContext(context.Background(), name, publicKey, rights)
+}
+
+func (a *ProtectedApi) AddIdentityContext(ctx context.Context, name string, publicKey *ecdsa.PublicKey, rights []*RightInput) (*AddIdentityResponse, error) {
 
 	key, err := helper.NewBase64PublicPem(publicKey)
 	if err != nil {
@@ -42,21 +63,25 @@ func (a *ProtectedApi) AddIdentity(name string, publicKey *ecdsa.PublicKey, righ
 		return nil, err
 	}
 
-	creatorSign, err := helper.SignCreatorJWT(a.authKey, newIdentityId, a.vaultId)
+	creatorSign, err := a.signer.SignCreatorJWT(newIdentityId, a.vaultId)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := addIdentity(context.Background(), a.client, name, key, creatorSign)
+	resp, err := addIdentity(ctx, a.client, name, key, creatorSign)
 	if err != nil {
 		return nil, err
 	}
+	if resp.AddIdentity == nil || len(resp.AddIdentity.Affected) == 0 {
+		return nil, ErrNothingCreated
+	}
 	identityId := resp.AddIdentity.Affected[0].Id
 
-	rightIds, err := a.AddRights(rights, identityId)
+	rightIds, err := a.addRights(ctx, rights, identityId)
 
 	if err != nil {
 		// ROLLBACK
-		err2 := a.DeleteIdentity(identityId)
+		// the rollback must not be skipped only because the caller context was cancelled
+		err2 := a.DeleteIdentityContext(context.WithoutCancel(ctx), identityId)
 		if err2 != nil {
 			e := errors.New("failed to rollback identity")
 			return nil, errors.Join(e, err2, err)
@@ -64,54 +89,167 @@ func (a *ProtectedApi) AddIdentity(name string, publicKey *ecdsa.PublicKey, righ
 		return nil, err
 	}
 
-	return &AddIdentityResponse{IdentityId: identityId, RightIds: rightIds, PublicKey: publicKey}, nil
+	response := &AddIdentityResponse{IdentityId: identityId, RightIds: rightIds, PublicKey: publicKey}
+	return response, a.autoShareValues(ctx, identityId)
+}
+
+func (a *ProtectedApi) UpdateIdentity(id string, name string, rights []*RightInput) (*UpdateIdentityResponse, error) {
+	return a.UpdateIdentityContext(context.Background(), id, name, rights)
 }
 
-func (a *ProtectedApi) UpdateIdentity(id string, name string, rights []*RightInput) (*AddIdentityResponse, error) {
-	uiresp, err := updateIdentity(context.Background(), a.client, id, name)
+// UpdateIdentityContext renames the identity and replaces its rights by the given ones.
+// New rights are added before obsolete rights are removed, so the identity is never left without rights.
+// On any failure the previous name and rights are restored.
+func (a *ProtectedApi) UpdateIdentityContext(ctx context.Context, id string, name string, rights []*RightInput) (*UpdateIdentityResponse, error) {
+	current, err := a.GetIdentityContext(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	_, err = deleteAllRightsFromIdentity(context.Background(), a.client, id)
+	pubKey, err := current.PublicKey.GetPublicKey()
 	if err != nil {
 		return nil, err
 	}
 
-	rightIds, err := a.AddRights(rights, id)
+	desired := make(map[RightDescription]*RightInput)
+	for _, v := range rights {
+		desired[RightDescription{Target: v.Target, Right: v.Right, RightValue: v.RightValuePattern}] = v
+	}
+	response := &UpdateIdentityResponse{
+		AddIdentityResponse: &AddIdentityResponse{IdentityId: id, RightIds: make([]string, 0), PublicKey: pubKey},
+	}
+	obsolete := make([]*getIdentityGetIdentityRightsRight, 0)
+	for _, v := range current.Rights {
+		description := RightDescription{Target: v.Target, Right: v.Right, RightValue: v.RightValuePattern}
+		if _, ok := desired[description]; ok {
+			delete(desired, description)
+			response.KeptRights = append(response.KeptRights, description)
+			response.RightIds = append(response.RightIds, v.Id)
+			continue
+		}
+		obsolete = append(obsolete, v)
+	}
+	toAdd := make([]*RightInput, 0, len(desired))
+	for _, v := range rights {
+		description := RightDescription{Target: v.Target, Right: v.Right, RightValue: v.RightValuePattern}
+		if _, ok := desired[description]; ok {
+			delete(desired, description)
+			toAdd = append(toAdd, v)
+		}
+	}
+
+	uiresp, err := updateIdentity(ctx, a.client, id, name)
 	if err != nil {
 		return nil, err
 	}
-	pubKey, err := uiresp.UpdateIdentity.Affected[0].PublicKey.GetPublicKey()
-	if err != nil {
+	if uiresp.UpdateIdentity == nil || len(uiresp.UpdateIdentity.Affected) == 0 {
+		return nil, ErrIdentityNotFound
+	}
+
+	addedIds := make([]string, 0)
+	removed := make([]*getIdentityGetIdentityRightsRight, 0)
+	rollback := func(err error) (*UpdateIdentityResponse, error) {
+		// the rollback must not be skipped only because the caller context was cancelled
+		if err2 := a.rollbackUpdateIdentity(context.WithoutCancel(ctx), current, addedIds, removed); err2 != nil {
+			e := errors.New("failed to rollback identity")
+			return nil, errors.Join(e, err2, err)
+		}
 		return nil, err
 	}
-	return &AddIdentityResponse{IdentityId: id, RightIds: rightIds, PublicKey: pubKey}, nil
+
+	if len(toAdd) > 0 {
+		addedIds, err = a.addRights(ctx, toAdd, id)
+		if err != nil {
+			return rollback(err)
+		}
+	}
+	for _, v := range toAdd {
+		response.AddedRights = append(response.AddedRights, RightDescription{Target: v.Target, Right: v.Right, RightValue: v.RightValuePattern})
+	}
+	response.RightIds = append(response.RightIds, addedIds...)
+
+	for _, v := range obsolete {
+		if err := ctx.Err(); err != nil {
+			return rollback(err)
+		}
+		if _, err := a.DeleteRightContext(ctx, v.Id, id); err != nil {
+			return rollback(err)
+		}
+		removed = append(removed, v)
+		response.RemovedRights = append(response.RemovedRights, RightDescription{Target: v.Target, Right: v.Right, RightValue: v.RightValuePattern})
+	}
+
+	return response, a.autoShareValues(ctx, id)
+}
+
+// rollbackUpdateIdentity restores the name and rights of previous after a failed UpdateIdentity
+func (a *ProtectedApi) rollbackUpdateIdentity(ctx context.Context, previous *getIdentityGetIdentity, addedIds []string, removed []*getIdentityGetIdentityRightsRight) error {
+	var rollbackErr error
+	if len(removed) > 0 {
+		restore := make([]*RightInput, 0, len(removed))
+		for _, v := range removed {
+			restore = append(restore, &RightInput{Target: v.Target, Right: v.Right, RightValuePattern: v.RightValuePattern})
+		}
+		if _, err := a.addRights(ctx, restore, previous.Id); err != nil {
+			rollbackErr = errors.Join(rollbackErr, err)
+		}
+	}
+	for _, v := range addedIds {
+		if _, err := a.DeleteRightContext(ctx, v, previous.Id); err != nil {
+			rollbackErr = errors.Join(rollbackErr, err)
+		}
+	}
+	name := ""
+	if previous.Name != nil {
+		name = *previous.Name
+	}
+	if _, err := updateIdentity(ctx, a.client, previous.Id, name); err != nil {
+		rollbackErr = errors.Join(rollbackErr, err)
+	}
+	return rollbackErr
 }
 
 func (a *ProtectedApi) GetIdentity(id string) (*getIdentityGetIdentity, error) {
-	resp, err := getIdentity(context.Background(), a.client, id)
-	return resp.GetIdentity, err
+	return a.GetIdentityContext(context.Background(), id)
+}
+
+func (a *ProtectedApi) GetIdentityContext(ctx context.Context, id string) (*getIdentityGetIdentity, error) {
+	resp, err := getIdentity(ctx, a.client, id)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetIdentity == nil {
+		return nil, ErrIdentityNotFound
+	}
+	return resp.GetIdentity, nil
 }
 
 func (a *ProtectedApi) CreateIdentity(name string, rights []*RightInput) (*CreateIdentityResponse, error) {
+	return a.CreateIdentityContext(context.Background(), name, rights)
+}
+
+func (a *ProtectedApi) CreateIdentityContext(ctx context.Context, name string, rights []*RightInput) (*CreateIdentityResponse, error) {
 	priv, pub, err := a.api.GetNewIdentityKeyPair()
 	if err != nil {
 		return nil, err
 	}
-	resp, err := a.AddIdentity(name, pub, rights)
-	if err != nil {
+	resp, err := a.AddIdentityContext(ctx, name, pub, rights)
+	if resp == nil {
 		return nil, err
 	}
-
+	// the identity exists even if sharing values failed, its key must not be lost
 	return &CreateIdentityResponse{
 		AddIdentityResponse: resp,
 		PrivateKey:          priv,
-	}, nil
+	}, err
 }
 
 func (a *ProtectedApi) DeleteIdentity(tokenId string) error {
+	return a.DeleteIdentityContext(context.Background(), tokenId)
+}
 
-	_, err := deleteIdentity(context.Background(), a.client, tokenId)
+func (a *ProtectedApi) DeleteIdentityContext(ctx context.Context, tokenId string) error {
+
+	_, err := deleteIdentity(ctx, a.client, tokenId)
 	if err != nil {
 		return err
 	}
@@ -119,5 +257,235 @@ func (a *ProtectedApi) DeleteIdentity(tokenId string) error {
 }
 
 func (a *ProtectedApi) GetAllIdentities() (*allIdentitiesResponse, error) {
-	return allIdentities(context.Background(), a.client)
+	return a.GetAllIdentitiesContext(context.Background())
+}
+
+func (a *ProtectedApi) GetAllIdentitiesContext(ctx context.Context) (*allIdentitiesResponse, error) {
+	return allIdentities(ctx, a.client)
+}
+
+func (a *ProtectedApi) RotateIdentityKey(identityId string, privateKey *ecdsa.PrivateKey) (*CreateIdentityResponse, error) {
+	return a.RotateIdentityKeyContext(context.Background(), identityId, privateKey)
+}
+
+// RotateIdentityKeyContext replaces the key pair of identity identityId by privateKey, a new key pair is generated if privateKey is nil.
+// The id of an identity is derived from its public key, so an identity with the same name and rights is created for the new key,
+// all values of the identity are encrypted for the new key, identities created by it are signed again with the new key
+// and the old identity is deleted last. Rights of other identities for IDENTITY.<identityId> are not changed.
+// Everything is prepared before the first change; if a change fails all previous changes are rolled back.
+// The new identity is signed by the caller, so an identity can not rotate its own key, it would lose its creator,
+// and operators can not be rotated at all, as the api can not create a replacing operator.
+func (a *ProtectedApi) RotateIdentityKeyContext(ctx context.Context, identityId string, privateKey *ecdsa.PrivateKey) (*CreateIdentityResponse, error) {
+	ownerPubKey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	ownerId, err := ownerPubKey.GetIdentityId(a.vaultId)
+	if err != nil {
+		return nil, err
+	}
+	if identityId == ownerId {
+		return nil, errors.New("an identity can not rotate its own key, the creator of the identity has to rotate it")
+	}
+	current, err := a.GetIdentityContext(ctx, identityId)
+	if err != nil {
+		return nil, err
+	}
+	if current.IsOperator {
+		return nil, fmt.Errorf("identity %s is an operator, its key can not be rotated", identityId)
+	}
+	if privateKey == nil {
+		privateKey, _, err = helper.GenerateNewKeyPair()
+		if err != nil {
+			return nil, err
+		}
+	}
+	newKey, err := helper.NewBase64PublicPem(&privateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	newId, err := newKey.GetIdentityId(a.vaultId)
+	if err != nil {
+		return nil, err
+	}
+	if newId == identityId {
+		return nil, errors.New("new key of the identity is equal to the current one")
+	}
+
+	passframes, err := a.reencryptIdentityValues(ctx, identityId, newId, newKey)
+	if err != nil {
+		return nil, err
+	}
+	children, err := a.resignCreatedIdentities(ctx, identityId, NewPrivateKeySigner(privateKey))
+	if err != nil {
+		return nil, err
+	}
+	creatorSign, err := a.signer.SignCreatorJWT(newId, a.vaultId)
+	if err != nil {
+		return nil, err
+	}
+	rights := make([]*RightInput, 0, len(current.Rights))
+	for _, v := range current.Rights {
+		rights = append(rights, &RightInput{Target: v.Target, Right: v.Right, RightValuePattern: v.RightValuePattern})
+	}
+	name := ""
+	if current.Name != nil {
+		name = *current.Name
+	}
+
+	resp, err := addIdentity(ctx, a.client, name, newKey, creatorSign)
+	if err != nil {
+		return nil, err
+	}
+	if resp.AddIdentity == nil || len(resp.AddIdentity.Affected) == 0 {
+		return nil, ErrNothingCreated
+	}
+
+	patched := make([]*creatorVerificationChange, 0, len(children))
+	rollback := func(err error) (*CreateIdentityResponse, error) {
+		// the rollback must not be skipped only because the caller context was cancelled
+		rollbackCtx := context.WithoutCancel(ctx)
+		rollbackErr := a.restoreCreatorVerifications(rollbackCtx, patched)
+		if err := a.DeleteIdentityContext(rollbackCtx, newId); err != nil {
+			rollbackErr = errors.Join(rollbackErr, err)
+		}
+		if rollbackErr != nil {
+			e := errors.New("failed to rollback identity")
+			return nil, errors.Join(e, rollbackErr, err)
+		}
+		return nil, err
+	}
+
+	rightIds, err := a.addRights(ctx, rights, newId)
+	if err != nil {
+		return rollback(err)
+	}
+	if len(passframes) > 0 {
+		if _, err := addIdentityValue(ctx, a.client, passframes); err != nil {
+			return rollback(err)
+		}
+	}
+	patched, err = a.applyCreatorVerifications(ctx, children)
+	if err != nil {
+		return rollback(err)
+	}
+	if err := a.DeleteIdentityContext(ctx, identityId); err != nil {
+		return rollback(err)
+	}
+
+	return &CreateIdentityResponse{
+		AddIdentityResponse: &AddIdentityResponse{IdentityId: newId, RightIds: rightIds, PublicKey: &privateKey.PublicKey},
+		PrivateKey:          privateKey,
+	}, nil
+}
+
+// reencryptIdentityValues decrypts every value identityId holds a passframe of and encrypts it for newKey
+func (a *ProtectedApi) reencryptIdentityValues(ctx context.Context, identityId, newId string, newKey helper.Base64PublicPem) ([]*IdentityValueInput, error) {
+	related, err := a.GetAllRelatedValuesWithIdentityValuesContext(ctx, identityId)
+	if err != nil {
+		return nil, err
+	}
+	ownerPubKey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	ownerId, err := ownerPubKey.GetIdentityId(a.vaultId)
+	if err != nil {
+		return nil, err
+	}
+
+	passframes := make([]*IdentityValueInput, 0)
+	for _, v := range related {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		held := helper.Includes(v.Value, func(iv *allRelatedValuesWithIdentityValuesAllRelatedValuesValueValueIdentityValue) bool {
+			return iv.IdentityID == identityId
+		})
+		if !held {
+			continue
+		}
+		value, err := a.GetValueByIdContext(ctx, v.Id)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]EncryptenValue, 0, len(value.Value))
+		for _, iv := range value.Value {
+			values = append(values, iv)
+		}
+		secret, err := a.getDecryptedPassframe(ownerId, values)
+		if err != nil {
+			return nil, fmt.Errorf("value %s: %w", v.Name, err)
+		}
+		passframe, err := newKey.Encrypt(secret)
+		if err != nil {
+			return nil, err
+		}
+		passframes = append(passframes, &IdentityValueInput{ValueID: v.Id, IdentityID: newId, Passframe: passframe})
+	}
+	return passframes, nil
+}
+
+type creatorVerificationChange struct {
+	identityId string
+	previous   string
+	next       string
+}
+
+// resignCreatedIdentities signs the creator verification of all identities created by creatorId again with signer
+func (a *ProtectedApi) resignCreatedIdentities(ctx context.Context, creatorId string, signer Signer) ([]*creatorVerificationChange, error) {
+	changes := make([]*creatorVerificationChange, 0)
+	for offset := 0; ; {
+		resp, err := identityCreatorVerifications(ctx, a.client, identityPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		if resp.QueryIdentity == nil {
+			return changes, nil
+		}
+		for _, v := range resp.QueryIdentity.Data {
+			if v.CreatorVerification == "" {
+				continue
+			}
+			message, _, err := helper.DecodeCreatorJWT(v.CreatorVerification)
+			if err != nil || message.CreatorTokenId != creatorId {
+				continue
+			}
+			next, err := signer.SignCreatorJWT(v.Id, a.vaultId)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, &creatorVerificationChange{identityId: v.Id, previous: v.CreatorVerification, next: next})
+		}
+		offset += len(resp.QueryIdentity.Data)
+		if len(resp.QueryIdentity.Data) < identityPageSize || offset >= resp.QueryIdentity.TotalCount {
+			return changes, nil
+		}
+	}
+}
+
+// applyCreatorVerifications writes the next creator verification of all changes and returns the written ones
+func (a *ProtectedApi) applyCreatorVerifications(ctx context.Context, changes []*creatorVerificationChange) ([]*creatorVerificationChange, error) {
+	applied := make([]*creatorVerificationChange, 0, len(changes))
+	for _, v := range changes {
+		if err := ctx.Err(); err != nil {
+			return applied, err
+		}
+		if _, err := updateIdentityCreatorVerification(ctx, a.client, v.identityId, v.next); err != nil {
+			return applied, err
+		}
+		applied = append(applied, v)
+	}
+	return applied, nil
+}
+
+// restoreCreatorVerifications writes back the previous creator verification of all changes
+func (a *ProtectedApi) restoreCreatorVerifications(ctx context.Context, changes []*creatorVerificationChange) error {
+	var restoreErr error
+	for _, v := range changes {
+		if _, err := updateIdentityCreatorVerification(ctx, a.client, v.identityId, v.previous); err != nil {
+			restoreErr = errors.Join(restoreErr, err)
+		}
+	}
+	return restoreErr
 }
@@ -0,0 +1,207 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cryptvault-cloud/api"
+	"github.com/cryptvault-cloud/api/vaulttest"
+	"github.com/cryptvault-cloud/helper"
+)
+
+func rightInputs(t *testing.T, patterns ...string) []*api.RightInput {
+	t.Helper()
+	result := make([]*api.RightInput, 0)
+	for _, pattern := range patterns {
+		rights, err := api.GetRightDescriptionByString(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range rights {
+			result = append(result, &api.RightInput{Target: v.Target, Right: v.Right, RightValuePattern: v.RightValue})
+		}
+	}
+	return result
+}
+
+func TestUpdateIdentityDiffsRights(t *testing.T) {
+	_, operator, _ := newTestVault(t)
+
+	identity, err := operator.CreateIdentity("service", rightInputs(t, "(r)VALUES.a.>", "(r)VALUES.b.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+
+	resp, err := operator.UpdateIdentity(identity.IdentityId, "renamed", rightInputs(t, "(r)VALUES.b.>", "(r)VALUES.c.>"))
+	if err != nil {
+		t.Fatalf("UpdateIdentity() error = %v", err)
+	}
+	if len(resp.AddedRights) != 1 || resp.AddedRights[0].RightValue != "VALUES.c.>" {
+		t.Errorf("AddedRights = %v, want VALUES.c.>", resp.AddedRights)
+	}
+	if len(resp.KeptRights) != 1 || resp.KeptRights[0].RightValue != "VALUES.b.>" {
+		t.Errorf("KeptRights = %v, want VALUES.b.>", resp.KeptRights)
+	}
+	if len(resp.RemovedRights) != 1 || resp.RemovedRights[0].RightValue != "VALUES.a.>" {
+		t.Errorf("RemovedRights = %v, want VALUES.a.>", resp.RemovedRights)
+	}
+	if len(resp.RightIds) != 2 {
+		t.Errorf("RightIds = %v, want 2 ids", resp.RightIds)
+	}
+
+	// the server rejects the invalid pattern, so the update must be rolled back
+	invalid := append(rightInputs(t, "(r)VALUES.d.>"), &api.RightInput{Target: api.RightTargetValues, Right: api.DirectionsRead, RightValuePattern: "VALUES..invalid"})
+	if _, err := operator.UpdateIdentity(identity.IdentityId, "broken", invalid); err == nil {
+		t.Fatal("UpdateIdentity() with invalid right error = nil")
+	}
+	current, err := operator.GetIdentity(identity.IdentityId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Name == nil || *current.Name != "renamed" {
+		t.Errorf("name after rollback = %v, want renamed", current.Name)
+	}
+	patterns := make(map[string]bool)
+	for _, v := range current.Rights {
+		patterns[v.RightValuePattern] = true
+	}
+	if len(patterns) != 2 || !patterns["VALUES.b.>"] || !patterns["VALUES.c.>"] {
+		t.Errorf("rights after rollback = %v, want VALUES.b.> and VALUES.c.>", patterns)
+	}
+}
+
+func TestAutoShareValues(t *testing.T) {
+	a, operator, vaultId := newTestVault(t, api.WithAutoShareValues())
+
+	valueId, err := operator.AddValue("VALUES.a.x", "secret", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	identity, err := operator.CreateIdentity("service", rightInputs(t, "(r)VALUES.a.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	value, err := a.GetProtectedApi(identity.PrivateKey, vaultId).GetIdentityValueById(valueId)
+	if err != nil {
+		t.Fatalf("GetIdentityValueById() error = %v", err)
+	}
+	if value.Value != "secret" {
+		t.Errorf("GetIdentityValueById() = %s, want secret", value.Value)
+	}
+
+	if _, err := operator.UpdateIdentity(identity.IdentityId, "service", rightInputs(t, "(r)VALUES.b.>")); err != nil {
+		t.Fatalf("UpdateIdentity() error = %v", err)
+	}
+	stored, err := operator.GetValueById(valueId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range stored.Value {
+		if v.IdentityID == identity.IdentityId {
+			t.Error("passframe of revoked identity was not deleted")
+		}
+	}
+}
+
+func TestCreateIdentityKeepsKeyIfSharingFails(t *testing.T) {
+	server := vaulttest.NewServer()
+	t.Cleanup(server.Close)
+	private, _, vaultId, err := api.NewApi(server.URL, http.DefaultClient).NewVault("test", "token")
+	if err != nil {
+		t.Fatalf("NewVault() error = %v", err)
+	}
+	if _, err := api.NewApi(server.URL, http.DefaultClient).GetProtectedApi(private, vaultId).AddValue("VALUES.a.x", "secret", api.ValueTypeString); err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	// pinning another operator makes the creator chain of the new identity untrusted, so sharing fails
+	_, other, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := api.NewApi(server.URL, http.DefaultClient, api.WithAutoShareValues(), api.WithTrustedOperators(other))
+	operator := a.GetProtectedApi(private, vaultId)
+
+	identity, err := operator.CreateIdentity("service", rightInputs(t, "(r)VALUES.a.>"))
+	if !errors.Is(err, api.ErrValueSharing) {
+		t.Fatalf("CreateIdentity() error = %v, want ErrValueSharing", err)
+	}
+	if identity == nil || identity.PrivateKey == nil {
+		t.Fatal("CreateIdentity() did not return the key of the created identity")
+	}
+	if _, err := a.GetProtectedApi(identity.PrivateKey, vaultId).GetIdentity(identity.IdentityId); err != nil {
+		t.Errorf("GetIdentity() with returned key error = %v", err)
+	}
+}
+
+func TestRotateIdentityKey(t *testing.T) {
+	a, operator, vaultId := newTestVault(t)
+	ctx := context.Background()
+
+	valueId, err := operator.AddValue("VALUES.a.x", "secret", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	service, err := operator.CreateIdentity("service", rightInputs(t, "(r)VALUES.a.>", "(rw)IDENTITY.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	serviceApi := a.GetProtectedApi(service.PrivateKey, vaultId)
+	if _, err := serviceApi.CreateIdentity("child", rightInputs(t, "(r)VALUES.a.>")); err != nil {
+		t.Fatalf("CreateIdentity() of child error = %v", err)
+	}
+	if err := operator.SyncValue(valueId); err != nil {
+		t.Fatalf("SyncValue() error = %v", err)
+	}
+
+	rotated, err := operator.RotateIdentityKeyContext(ctx, service.IdentityId, nil)
+	if err != nil {
+		t.Fatalf("RotateIdentityKey() error = %v", err)
+	}
+	if rotated.IdentityId == service.IdentityId {
+		t.Fatal("RotateIdentityKey() kept the identity id of the old key")
+	}
+	value, err := a.GetProtectedApi(rotated.PrivateKey, vaultId).GetIdentityValueById(valueId)
+	if err != nil {
+		t.Fatalf("GetIdentityValueById() with new key error = %v", err)
+	}
+	if value.Value != "secret" {
+		t.Errorf("GetIdentityValueById() with new key = %s, want secret", value.Value)
+	}
+	if _, err := serviceApi.GetIdentityValueById(valueId); err == nil {
+		t.Error("GetIdentityValueById() with old key succeeded")
+	}
+	identity, err := operator.GetIdentity(rotated.IdentityId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(identity.Rights) != 3 {
+		t.Errorf("rotated identity has %d rights, want 3", len(identity.Rights))
+	}
+	// SyncValue verifies the creator chain of the child, which now points to the new key
+	if err := operator.SyncValue(valueId); err != nil {
+		t.Errorf("SyncValue() after rotation error = %v", err)
+	}
+}
+
+func TestRotateIdentityKeyOfItself(t *testing.T) {
+	a, operator, vaultId := newTestVault(t)
+	ctx := context.Background()
+
+	service, err := operator.CreateIdentity("service", rightInputs(t, "(r)VALUES.a.>", "(rwd)IDENTITY.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	serviceApi := a.GetProtectedApi(service.PrivateKey, vaultId)
+	// the new identity would be signed by the deleted old one and lose its creator chain
+	if _, err := serviceApi.RotateIdentityKeyContext(ctx, service.IdentityId, nil); err == nil {
+		t.Fatal("RotateIdentityKey() of itself succeeded")
+	}
+	if _, err := operator.GetIdentity(service.IdentityId); err != nil {
+		t.Errorf("GetIdentity() after rejected rotation error = %v", err)
+	}
+	if _, err := operator.AddValue("VALUES.a.x", "secret", api.ValueTypeString); err != nil {
+		t.Errorf("AddValue() after rejected rotation error = %v", err)
+	}
+}
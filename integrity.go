@@ -0,0 +1,235 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+// IntegrityIssueKind classifies a problem found by VerifyVaultIntegrity
+type IntegrityIssueKind string
+
+const (
+	// IntegrityKeyMismatch the id of the identity is not derived from its public key
+	IntegrityKeyMismatch IntegrityIssueKind = "key_mismatch"
+	// IntegrityInvalidSignature the creator verification can not be decoded, is not signed by the creator
+	// or names another identity or vault
+	IntegrityInvalidSignature IntegrityIssueKind = "invalid_signature"
+	// IntegrityOrphaned the creator of the identity does not exist
+	IntegrityOrphaned IntegrityIssueKind = "orphaned"
+	// IntegrityCycle following the creators of the identity leads back to an identity already visited
+	IntegrityCycle IntegrityIssueKind = "cycle"
+	// IntegrityBrokenChain a creator further up the chain has an issue itself
+	IntegrityBrokenChain IntegrityIssueKind = "broken_chain"
+	// IntegrityNoOperator the vault has no valid operator
+	IntegrityNoOperator IntegrityIssueKind = "no_operator"
+	// IntegrityUntrustedOperator the identity is flagged as operator but is no pinned trust anchor, see WithTrustedOperators
+	IntegrityUntrustedOperator IntegrityIssueKind = "untrusted_operator"
+)
+
+// IntegrityIssue is a single problem found by VerifyVaultIntegrity
+type IntegrityIssue struct {
+	IdentityId string             `json:"identityId,omitempty"`
+	Kind       IntegrityIssueKind `json:"kind"`
+	Message    string             `json:"message"`
+}
+
+// IntegrityReport is the result of VerifyVaultIntegrity
+type IntegrityReport struct {
+	VaultId   string    `json:"vaultId"`
+	CheckedAt time.Time `json:"checkedAt"`
+	// Identities is the number of identities visible to the calling identity
+	Identities int `json:"identities"`
+	// Operators lists the ids of all valid operators, the pinned trust anchors if configured
+	Operators []string `json:"operators"`
+	// Verified lists the ids of all identities with a valid signature chain to an operator, operators included
+	Verified []string         `json:"verified"`
+	Issues   []IntegrityIssue `json:"issues"`
+}
+
+// OK reports whether no issue was found
+func (r *IntegrityReport) OK() bool {
+	return len(r.Issues) == 0
+}
+
+// identityPageSize is the number of identities loaded per request while checking or re-signing creator chains
+const identityPageSize = 100
+
+// loadVaultIdentities pages through all identities visible to the calling identity
+func (a *ProtectedApi) loadVaultIdentities(ctx context.Context) ([]*vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity, error) {
+	identities := make([]*vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity, 0)
+	for offset := 0; ; {
+		resp, err := vaultIdentities(ctx, a.client, identityPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		if resp.QueryIdentity == nil {
+			return identities, nil
+		}
+		identities = append(identities, resp.QueryIdentity.Data...)
+		offset += len(resp.QueryIdentity.Data)
+		if len(resp.QueryIdentity.Data) < identityPageSize || offset >= resp.QueryIdentity.TotalCount {
+			return identities, nil
+		}
+	}
+}
+
+func (a *ProtectedApi) VerifyVaultIntegrity() (*IntegrityReport, error) {
+	return a.VerifyVaultIntegrityContext(context.Background())
+}
+
+// VerifyVaultIntegrityContext checks every identity visible to the calling identity: its id must match its public key
+// and its creator verification must be signed by its creator, following the creators back to an operator.
+// The returned error is only set if the identities could not be loaded, all findings are part of the report.
+func (a *ProtectedApi) VerifyVaultIntegrityContext(ctx context.Context) (*IntegrityReport, error) {
+	identities, err := a.loadVaultIdentities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &IntegrityReport{
+		VaultId:   a.vaultId,
+		CheckedAt: time.Now(),
+		Operators: make([]string, 0),
+		Verified:  make([]string, 0),
+		Issues:    make([]IntegrityIssue, 0),
+	}
+	if len(identities) == 0 {
+		report.Issues = append(report.Issues, IntegrityIssue{Kind: IntegrityNoOperator, Message: "no identities found"})
+		return report, nil
+	}
+	report.Identities = len(identities)
+
+	byId := make(map[string]*vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity, len(identities))
+	keys := make(map[string]*ecdsa.PublicKey, len(identities))
+	for _, v := range identities {
+		byId[v.Id] = v
+		key, err := identityPublicKey(v.PublicKey, v.Id, a.vaultId)
+		if err != nil {
+			report.Issues = append(report.Issues, IntegrityIssue{IdentityId: v.Id, Kind: IntegrityKeyMismatch, Message: err.Error()})
+			continue
+		}
+		keys[v.Id] = key
+	}
+
+	// creators holds the verified link of every identity to its creator
+	creators := make(map[string]string, len(identities))
+	broken := make(map[string]bool)
+	for _, v := range identities {
+		if _, ok := keys[v.Id]; !ok {
+			broken[v.Id] = true
+			continue
+		}
+		if a.isTrustAnchor(v.Id, v.IsOperator) {
+			continue
+		}
+		if v.IsOperator {
+			broken[v.Id] = true
+			report.Issues = append(report.Issues, IntegrityIssue{IdentityId: v.Id, Kind: IntegrityUntrustedOperator, Message: "operator is no pinned trust anchor"})
+			continue
+		}
+		message, _, err := helper.DecodeCreatorJWT(v.CreatorVerification)
+		if err != nil {
+			broken[v.Id] = true
+			report.Issues = append(report.Issues, IntegrityIssue{IdentityId: v.Id, Kind: IntegrityInvalidSignature, Message: err.Error()})
+			continue
+		}
+		if _, ok := byId[message.CreatorTokenId]; !ok {
+			broken[v.Id] = true
+			report.Issues = append(report.Issues, IntegrityIssue{IdentityId: v.Id, Kind: IntegrityOrphaned, Message: fmt.Sprintf("creator %s not found", message.CreatorTokenId)})
+			continue
+		}
+		creatorKey, ok := keys[message.CreatorTokenId]
+		if !ok {
+			broken[v.Id] = true
+			report.Issues = append(report.Issues, IntegrityIssue{IdentityId: v.Id, Kind: IntegrityBrokenChain, Message: fmt.Sprintf("creator %s has an invalid public key", message.CreatorTokenId)})
+			continue
+		}
+		if _, err := verifyCreatorVerification(creatorKey, v.Id, a.vaultId, v.CreatorVerification); err != nil {
+			broken[v.Id] = true
+			report.Issues = append(report.Issues, IntegrityIssue{IdentityId: v.Id, Kind: IntegrityInvalidSignature, Message: err.Error()})
+			continue
+		}
+		creators[v.Id] = message.CreatorTokenId
+	}
+
+	for _, v := range identities {
+		if broken[v.Id] {
+			continue
+		}
+		if a.isTrustAnchor(v.Id, v.IsOperator) {
+			report.Operators = append(report.Operators, v.Id)
+			report.Verified = append(report.Verified, v.Id)
+			continue
+		}
+		if issue := a.walkCreatorChain(v.Id, byId, creators, broken); issue != nil {
+			report.Issues = append(report.Issues, *issue)
+			continue
+		}
+		report.Verified = append(report.Verified, v.Id)
+	}
+	if len(report.Operators) == 0 {
+		report.Issues = append(report.Issues, IntegrityIssue{Kind: IntegrityNoOperator, Message: "vault has no valid operator"})
+	}
+	return report, nil
+}
+
+// walkCreatorChain follows the verified creators of id up to an operator and returns the issue found on the way
+func (a *ProtectedApi) walkCreatorChain(id string, byId map[string]*vaultIdentitiesQueryIdentityIdentityQueryResultDataIdentity, creators map[string]string, broken map[string]bool) *IntegrityIssue {
+	path := []string{id}
+	seen := map[string]bool{id: true}
+	current := creators[id]
+	for {
+		if broken[current] {
+			return &IntegrityIssue{IdentityId: id, Kind: IntegrityBrokenChain, Message: fmt.Sprintf("creator %s has an issue", current)}
+		}
+		if seen[current] {
+			return &IntegrityIssue{IdentityId: id, Kind: IntegrityCycle, Message: fmt.Sprintf("creator chain %s leads back to %s", strings.Join(path, " -> "), current)}
+		}
+		if a.isTrustAnchor(current, byId[current].IsOperator) {
+			return nil
+		}
+		seen[current] = true
+		path = append(path, current)
+		current = creators[current]
+	}
+}
+
+// identityPublicKey decodes publicKey and checks that id is derived from it
+func identityPublicKey(publicKey helper.Base64PublicPem, id, vaultId string) (*ecdsa.PublicKey, error) {
+	key, err := publicKey.GetPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	keyId, err := helper.GetIdFromPublicKey(key, vaultId)
+	if err != nil {
+		return nil, err
+	}
+	if keyId != id {
+		return nil, fmt.Errorf("public key belongs to identity %s", keyId)
+	}
+	return key, nil
+}
+
+// verifyCreatorVerification checks that creatorVerification is signed by creatorKey for identity id of vault vaultId
+func verifyCreatorVerification(creatorKey *ecdsa.PublicKey, id, vaultId, creatorVerification string) (*helper.SignCreatorMessage, error) {
+	message, messageJson, err := helper.DecodeCreatorJWT(creatorVerification)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCreatorVerification, err)
+	}
+	if message.TokenId != id || message.VaultId != vaultId {
+		return nil, fmt.Errorf("%w: issued for identity %s of vault %s", ErrInvalidCreatorVerification, message.TokenId, message.VaultId)
+	}
+	parts := strings.Split(creatorVerification, ".")
+	ok, err := helper.Verify(creatorKey, messageJson, parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCreatorVerification, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: signature does not match creator %s", ErrInvalidCreatorVerification, message.CreatorTokenId)
+	}
+	return message, nil
+}
@@ -0,0 +1,121 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/cryptvault-cloud/api"
+	"github.com/cryptvault-cloud/api/vaulttest"
+	"github.com/cryptvault-cloud/helper"
+)
+
+func TestVerifyVaultIntegrity(t *testing.T) {
+	server := vaulttest.NewServer()
+	t.Cleanup(server.Close)
+	a := api.NewApi(server.URL, http.DefaultClient)
+	private, _, vaultId, err := a.NewVault("test", "token")
+	if err != nil {
+		t.Fatalf("NewVault() error = %v", err)
+	}
+	operator := a.GetProtectedApi(private, vaultId)
+	ctx := context.Background()
+
+	create := func(creator api.ProtectedApiHandler, name string) *api.CreateIdentityResponse {
+		t.Helper()
+		identity, err := creator.CreateIdentity(name, rightInputs(t, "(rw)IDENTITY.>"))
+		if err != nil {
+			t.Fatalf("CreateIdentity(%s) error = %v", name, err)
+		}
+		return identity
+	}
+	parent := create(operator, "parent")
+	child := create(a.GetProtectedApi(parent.PrivateKey, vaultId), "child")
+
+	report, err := operator.VerifyVaultIntegrityContext(ctx)
+	if err != nil {
+		t.Fatalf("VerifyVaultIntegrity() error = %v", err)
+	}
+	if !report.OK() || len(report.Verified) != 3 || len(report.Operators) != 1 {
+		t.Fatalf("VerifyVaultIntegrity() = %+v, want 3 verified identities without issues", report)
+	}
+
+	orphanCreator := create(operator, "orphanCreator")
+	orphan := create(a.GetProtectedApi(orphanCreator.PrivateKey, vaultId), "orphan")
+	if err := operator.DeleteIdentity(orphanCreator.IdentityId); err != nil {
+		t.Fatal(err)
+	}
+	mismatch := create(operator, "mismatch")
+	invalid := create(operator, "invalid")
+	// parent and child sign each other
+	cycle, err := helper.SignCreatorJWT(child.PrivateKey, parent.IdentityId, vaultId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Handler.SetCreatorVerification(parent.IdentityId, cycle); err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := helper.NewBase64PublicPem(invalid.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Handler.SetPublicKey(mismatch.IdentityId, otherKey); err != nil {
+		t.Fatal(err)
+	}
+	// a creator verification issued for another identity
+	foreign, err := helper.SignCreatorJWT(private, mismatch.IdentityId, vaultId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Handler.SetCreatorVerification(invalid.IdentityId, foreign); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err = operator.VerifyVaultIntegrityContext(ctx)
+	if err != nil {
+		t.Fatalf("VerifyVaultIntegrity() error = %v", err)
+	}
+	got := make(map[string]api.IntegrityIssueKind)
+	for _, v := range report.Issues {
+		got[v.IdentityId] = v.Kind
+	}
+	want := map[string]api.IntegrityIssueKind{
+		parent.IdentityId:   api.IntegrityCycle,
+		child.IdentityId:    api.IntegrityCycle,
+		orphan.IdentityId:   api.IntegrityOrphaned,
+		mismatch.IdentityId: api.IntegrityKeyMismatch,
+		invalid.IdentityId:  api.IntegrityInvalidSignature,
+	}
+	if len(got) != len(want) {
+		t.Errorf("VerifyVaultIntegrity() issues = %+v, want %v", report.Issues, want)
+	}
+	for id, kind := range want {
+		if got[id] != kind {
+			t.Errorf("issue of identity %s = %q, want %q", id, got[id], kind)
+		}
+	}
+	if len(report.Verified) != 1 {
+		t.Errorf("VerifyVaultIntegrity() verified = %v, want only the operator", report.Verified)
+	}
+}
+
+func TestVerifyVaultIntegrityPages(t *testing.T) {
+	_, operator, _ := newTestVault(t)
+	ctx := context.Background()
+
+	// more identities than fit into one page of the identity queries
+	const identities = 120
+	for i := 1; i < identities; i++ {
+		if _, err := operator.CreateIdentity(fmt.Sprintf("service-%d", i), rightInputs(t, "(r)VALUES.a.>")); err != nil {
+			t.Fatalf("CreateIdentity() error = %v", err)
+		}
+	}
+	report, err := operator.VerifyVaultIntegrityContext(ctx)
+	if err != nil {
+		t.Fatalf("VerifyVaultIntegrity() error = %v", err)
+	}
+	if !report.OK() || report.Identities != identities || len(report.Verified) != identities {
+		t.Errorf("VerifyVaultIntegrity() = %d identities, %d verified, issues %+v, want %d verified", report.Identities, len(report.Verified), report.Issues, identities)
+	}
+}
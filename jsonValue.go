@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONSchema validates a decoded JSON document, numbers are passed as json.Number.
+// It is satisfied f.e.: by *jsonschema.Schema of github.com/santhosh-tekuri/jsonschema.
+type JSONSchema interface {
+	Validate(doc any) error
+}
+
+// JSONOption configures AddJSONValue, UpdateJSONValue and GetJSONValue
+type JSONOption func(*jsonOptions)
+
+type jsonOptions struct {
+	schema JSONSchema
+}
+
+// WithJSONSchema validates the value against schema before it is encrypted and after it is decrypted.
+// Validation errors are wrapped in ErrSchemaValidation.
+func WithJSONSchema(schema JSONSchema) JSONOption {
+	return func(o *jsonOptions) {
+		o.schema = schema
+	}
+}
+
+func AddJSONValue[T any](api ValueHandler, name string, value T, opts ...JSONOption) (string, error) {
+	return AddJSONValueContext(context.Background(), api, name, value, opts...)
+}
+
+// AddJSONValueContext adds value marshaled as JSON value with name, see AddValueContext
+func AddJSONValueContext[T any](ctx context.Context, api ValueHandler, name string, value T, opts ...JSONOption) (string, error) {
+	data, err := marshalJSONValue(value, opts)
+	if err != nil {
+		return "", err
+	}
+	return api.AddValueContext(ctx, name, data, ValueTypeJson)
+}
+
+func UpdateJSONValue[T any](api ValueHandler, id, name string, value T, opts ...JSONOption) (string, error) {
+	return UpdateJSONValueContext(context.Background(), api, id, name, value, opts...)
+}
+
+// UpdateJSONValueContext replaces the value with id by value marshaled as JSON, see UpdateValueContext.
+// It fails with ErrValueTypeMismatch if the stored value is no JSON value.
+func UpdateJSONValueContext[T any](ctx context.Context, api ValueHandler, id, name string, value T, opts ...JSONOption) (string, error) {
+	current, err := api.GetValueByIdContext(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if current.Type != ValueTypeJson {
+		return "", fmt.Errorf("%w: value %s has type %s", ErrValueTypeMismatch, current.Name, current.Type)
+	}
+	data, err := marshalJSONValue(value, opts)
+	if err != nil {
+		return "", err
+	}
+	return api.UpdateValueContext(ctx, id, name, data, ValueTypeJson)
+}
+
+func GetJSONValue[T any](api ValueHandler, name string, opts ...JSONOption) (T, error) {
+	return GetJSONValueContext[T](context.Background(), api, name, opts...)
+}
+
+// GetJSONValueContext decrypts the value with name and unmarshals it into a T.
+// It fails with ErrValueTypeMismatch if the stored value is no JSON value.
+func GetJSONValueContext[T any](ctx context.Context, api ValueHandler, name string, opts ...JSONOption) (T, error) {
+	var result T
+	value, err := api.GetIdentityValueByNameContext(ctx, name)
+	if err != nil {
+		return result, err
+	}
+	if value.Type != ValueTypeJson {
+		return result, fmt.Errorf("%w: value %s has type %s", ErrValueTypeMismatch, value.Name, value.Type)
+	}
+	if err := validateJSONValue([]byte(value.Value), opts); err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal([]byte(value.Value), &result); err != nil {
+		return result, fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+	return result, nil
+}
+
+func marshalJSONValue(value any, opts []JSONOption) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+	if err := validateJSONValue(data, opts); err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// validateJSONValue checks data against the schema set by opts
+func validateJSONValue(data []byte, opts []JSONOption) error {
+	var o jsonOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.schema == nil {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+	}
+	if err := o.schema.Validate(doc); err != nil {
+		return fmt.Errorf("%w: %w", ErrSchemaValidation, err)
+	}
+	return nil
+}
+
+// checkValueContent returns ErrInvalidJSON if a value of type JSON is not valid JSON
+func checkValueContent(value string, valueType ValueType) error {
+	if valueType == ValueTypeJson && !json.Valid([]byte(value)) {
+		return ErrInvalidJSON
+	}
+	return nil
+}
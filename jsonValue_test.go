@@ -0,0 +1,76 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cryptvault-cloud/api"
+)
+
+type database struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+// portSchema stands for a JSON Schema requiring a port between 1 and 65535
+type portSchema struct{}
+
+func (portSchema) Validate(doc any) error {
+	object, ok := doc.(map[string]any)
+	if !ok {
+		return errors.New("expected object")
+	}
+	number, ok := object["port"].(json.Number)
+	if !ok {
+		return errors.New("port is required")
+	}
+	port, err := number.Int64()
+	if err != nil || port < 1 || port > 65535 {
+		return errors.New("port out of range")
+	}
+	return nil
+}
+
+func TestJSONValues(t *testing.T) {
+	_, operator, _ := newTestVault(t)
+	ctx := context.Background()
+	schema := api.WithJSONSchema(portSchema{})
+
+	id, err := api.AddJSONValueContext(ctx, operator, "VALUES.db", database{Host: "localhost", Port: 5432}, schema)
+	if err != nil {
+		t.Fatalf("AddJSONValue() error = %v", err)
+	}
+	got, err := api.GetJSONValueContext[database](ctx, operator, "VALUES.db", schema)
+	if err != nil {
+		t.Fatalf("GetJSONValue() error = %v", err)
+	}
+	if got != (database{Host: "localhost", Port: 5432}) {
+		t.Errorf("GetJSONValue() = %+v", got)
+	}
+
+	if _, err := api.UpdateJSONValueContext(ctx, operator, id, "VALUES.db", database{Host: "db", Port: 0}, schema); !errors.Is(err, api.ErrSchemaValidation) {
+		t.Errorf("UpdateJSONValue() with invalid port error = %v, want ErrSchemaValidation", err)
+	}
+	if _, err := api.UpdateJSONValueContext(ctx, operator, id, "VALUES.db", database{Host: "db", Port: 5433}, schema); err != nil {
+		t.Fatalf("UpdateJSONValue() error = %v", err)
+	}
+	if got, err := api.GetJSONValue[database](operator, "VALUES.db"); err != nil || got.Host != "db" {
+		t.Errorf("GetJSONValue() after update = %+v, %v, want host db", got, err)
+	}
+
+	plainId, err := operator.AddValue("VALUES.plain", "text", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	if _, err := api.GetJSONValueContext[database](ctx, operator, "VALUES.plain"); !errors.Is(err, api.ErrValueTypeMismatch) {
+		t.Errorf("GetJSONValue() of string value error = %v, want ErrValueTypeMismatch", err)
+	}
+	if _, err := api.UpdateJSONValueContext(ctx, operator, plainId, "VALUES.plain", database{}); !errors.Is(err, api.ErrValueTypeMismatch) {
+		t.Errorf("UpdateJSONValue() of string value error = %v, want ErrValueTypeMismatch", err)
+	}
+	if _, err := operator.AddValue("VALUES.broken", "{not json", api.ValueTypeJson); !errors.Is(err, api.ErrInvalidJSON) {
+		t.Errorf("AddValue() with invalid JSON error = %v, want ErrInvalidJSON", err)
+	}
+}
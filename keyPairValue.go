@@ -0,0 +1,113 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// KeyPair is a private key with its public key read by GetKeyPairValue
+type KeyPair struct {
+	PrivateKey crypto.Signer
+	// PrivateKeyPEM is the private key as it was added
+	PrivateKeyPEM []byte
+	// PublicKeyPEM is the PKIX encoded public key
+	PublicKeyPEM []byte
+	// AuthorizedKey is the public key in the format of ssh authorized_keys files
+	AuthorizedKey string
+	// Algorithm is the ssh name of the key type, f.e.: ssh-ed25519
+	Algorithm string
+}
+
+func (k *KeyPair) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
+
+type keyPairEnvelope struct {
+	Kind          ValueKind `json:"kind"`
+	Algorithm     string    `json:"algorithm"`
+	PrivateKey    string    `json:"privateKey"`
+	PublicKey     string    `json:"publicKey"`
+	AuthorizedKey string    `json:"authorizedKey"`
+}
+
+func (a *ProtectedApi) AddKeyPairValue(name string, privateKeyPEM []byte) (string, error) {
+	return a.AddKeyPairValueContext(context.Background(), name, privateKeyPEM)
+}
+
+// AddKeyPairValueContext adds the unencrypted PEM encoded private key as value with name together with its public key.
+// PKCS#1, PKCS#8, SEC 1 and OpenSSH keys are accepted, the key is parsed before it is encrypted and fails
+// with ErrInvalidKeyPair otherwise.
+func (a *ProtectedApi) AddKeyPairValueContext(ctx context.Context, name string, privateKeyPEM []byte) (string, error) {
+	keyPair, err := parseKeyPair(privateKeyPEM)
+	if err != nil {
+		return "", err
+	}
+	return a.addEnvelope(ctx, name, &keyPairEnvelope{
+		Kind:          ValueKindKeyPair,
+		Algorithm:     keyPair.Algorithm,
+		PrivateKey:    string(keyPair.PrivateKeyPEM),
+		PublicKey:     string(keyPair.PublicKeyPEM),
+		AuthorizedKey: keyPair.AuthorizedKey,
+	})
+}
+
+func (a *ProtectedApi) GetKeyPairValue(name string) (*KeyPair, error) {
+	return a.GetKeyPairValueContext(context.Background(), name)
+}
+
+// GetKeyPairValueContext decrypts and parses the key pair with name. It fails with ErrValueTypeMismatch if the value
+// was not added by AddKeyPairValue and with ErrInvalidKeyPair if the stored public key does not match.
+func (a *ProtectedApi) GetKeyPairValueContext(ctx context.Context, name string) (*KeyPair, error) {
+	envelope := new(keyPairEnvelope)
+	if err := a.getEnvelope(ctx, name, ValueKindKeyPair, envelope); err != nil {
+		return nil, err
+	}
+	keyPair, err := parseKeyPair([]byte(envelope.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(keyPair.PublicKeyPEM, []byte(envelope.PublicKey)) {
+		return nil, fmt.Errorf("%w: public key of %s does not match its private key", ErrInvalidKeyPair, name)
+	}
+	return keyPair, nil
+}
+
+func parseKeyPair(privateKeyPEM []byte) (*KeyPair, error) {
+	if block, _ := pem.Decode(privateKeyPEM); block == nil {
+		return nil, fmt.Errorf("%w: no PEM private key found", ErrInvalidKeyPair)
+	}
+	raw, err := ssh.ParseRawPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyPair, err)
+	}
+	// OpenSSH ed25519 keys are returned as pointer
+	if key, ok := raw.(*ed25519.PrivateKey); ok {
+		raw = *key
+	}
+	privateKey, ok := raw.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported private key %T", ErrInvalidKeyPair, raw)
+	}
+	der, err := x509.MarshalPKIXPublicKey(privateKey.Public())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyPair, err)
+	}
+	sshKey, err := ssh.NewPublicKey(privateKey.Public())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKeyPair, err)
+	}
+	return &KeyPair{
+		PrivateKey:    privateKey,
+		PrivateKeyPEM: privateKeyPEM,
+		PublicKeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		AuthorizedKey: string(bytes.TrimSpace(ssh.MarshalAuthorizedKey(sshKey))),
+		Algorithm:     sshKey.Type(),
+	}, nil
+}
@@ -0,0 +1,64 @@
+package api_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cryptvault-cloud/api"
+)
+
+func TestKeyPairValues(t *testing.T) {
+	_, operator, _ := newTestVault(t)
+	ctx := context.Background()
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	sec1, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey() error = %v", err)
+	}
+	for name, test := range map[string]struct {
+		pem       []byte
+		algorithm string
+	}{
+		"VALUES.keys.ec":      {pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}), "ecdsa-sha2-nistp256"},
+		"VALUES.keys.ed25519": {pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: mustPKCS8(t)}), "ssh-ed25519"},
+	} {
+		if _, err := operator.AddKeyPairValueContext(ctx, name, test.pem); err != nil {
+			t.Fatalf("AddKeyPairValue(%s) error = %v", name, err)
+		}
+		got, err := operator.GetKeyPairValueContext(ctx, name)
+		if err != nil {
+			t.Fatalf("GetKeyPairValue(%s) error = %v", name, err)
+		}
+		if got.Algorithm != test.algorithm || !strings.HasPrefix(got.AuthorizedKey, test.algorithm+" ") {
+			t.Errorf("GetKeyPairValue(%s) algorithm = %s, authorized key = %s, want %s", name, got.Algorithm, got.AuthorizedKey, test.algorithm)
+		}
+		if block, _ := pem.Decode(got.PublicKeyPEM); block == nil || block.Type != "PUBLIC KEY" {
+			t.Errorf("GetKeyPairValue(%s) public key = %s", name, got.PublicKeyPEM)
+		}
+	}
+	if got, err := operator.GetKeyPairValueContext(ctx, "VALUES.keys.ec"); err != nil || !got.PrivateKey.(*ecdsa.PrivateKey).Equal(ecKey) {
+		t.Errorf("GetKeyPairValue() private key does not match, error = %v", err)
+	}
+
+	if _, err := operator.AddKeyPairValueContext(ctx, "VALUES.keys.invalid", []byte("not a key")); !errors.Is(err, api.ErrInvalidKeyPair) {
+		t.Errorf("AddKeyPairValue() with garbage error = %v, want ErrInvalidKeyPair", err)
+	}
+	if _, err := operator.AddCertificateValueContext(ctx, "VALUES.cert", selfSignedCertificate(t, "leaf", time.Now().Add(time.Hour))); err != nil {
+		t.Fatalf("AddCertificateValue() error = %v", err)
+	}
+	if _, err := operator.GetKeyPairValueContext(ctx, "VALUES.cert"); !errors.Is(err, api.ErrValueTypeMismatch) {
+		t.Errorf("GetKeyPairValue() of certificate error = %v, want ErrValueTypeMismatch", err)
+	}
+}
@@ -0,0 +1,293 @@
+package api
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/cryptvault-cloud/helper"
+)
+
+// Option configures an Api created by NewApi. All options apply to the public client and to
+// every ProtectedApi created through GetProtectedApi.
+type Option func(*options)
+
+type options struct {
+	timeout      time.Duration
+	userAgent    string
+	headers      http.Header
+	logger       *slog.Logger
+	retryPolicy  *RetryPolicy
+	tracer       Tracer
+	tokenSkew    time.Duration
+	autoShare    bool
+	valueHistory int
+	operatorKeys []*ecdsa.PublicKey
+	operatorIds  []string
+	cacheTTL     time.Duration
+	cacheSize    int
+	watchEvery   time.Duration
+	watcher      WatchTransport
+	chunkSize    int
+}
+
+// Tracer is called for every graphql operation. StartOperation returns the context used for
+// the request and a function which is called with the result of the operation.
+type Tracer interface {
+	StartOperation(ctx context.Context, opName string) (context.Context, func(err error))
+}
+
+// WithTimeout sets the timeout of every http request
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every http request
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithExtraHeaders adds headers to every http request
+func WithExtraHeaders(headers http.Header) Option {
+	return func(o *options) {
+		if o.headers == nil {
+			o.headers = make(http.Header)
+		}
+		for k, v := range headers {
+			for _, value := range v {
+				o.headers.Add(k, value)
+			}
+		}
+	}
+}
+
+// WithLogger logs every graphql operation with its duration and error
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithRetryPolicy retries failed graphql operations as described by policy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = &policy
+	}
+}
+
+// WithTracer traces every graphql operation
+func WithTracer(tracer Tracer) Option {
+	return func(o *options) {
+		o.tracer = tracer
+	}
+}
+
+// WithTokenRefreshSkew sets how long before its expiry the cached JWT of a ProtectedApi is signed again.
+// Default is DefaultTokenRefreshSkew.
+func WithTokenRefreshSkew(skew time.Duration) Option {
+	return func(o *options) {
+		o.tokenSkew = skew
+	}
+}
+
+func (o *options) tokenRefreshSkew() time.Duration {
+	if o.tokenSkew > 0 {
+		return o.tokenSkew
+	}
+	return DefaultTokenRefreshSkew
+}
+
+// WithAutoShareValues lets AddIdentity, UpdateIdentity and AddRights share all values the changed
+// identity can read afterwards and remove its passframes of values it can no longer read.
+// If sharing fails the rights change is kept, the result is returned together with an error wrapping ErrValueSharing.
+func WithAutoShareValues() Option {
+	return func(o *options) {
+		o.autoShare = true
+	}
+}
+
+// WithValueHistory keeps the last versions prior secrets of every value when it is updated or rotated.
+// Each version is stored as companion value below the name of the value, f.e.: VALUES.a.b._version.1,
+// so only identities with rights for this name can read it. Default is 0, no history is kept.
+// Updating or rotating a value then also writes and prunes versions, so the identity needs write and delete rights
+// covering the versions as well, f.e.: VALUES.a.b.> next to VALUES.a.b, otherwise the change fails and the value
+// is left as it was.
+func WithValueHistory(versions int) Option {
+	return func(o *options) {
+		o.valueHistory = versions
+	}
+}
+
+// WithTrustedOperators pins the public keys of the operators. Creator chains of identities are then only trusted
+// if they end at one of these operators, the isOperator flag returned by the server is ignored.
+// It can be combined with WithTrustedOperatorIds.
+func WithTrustedOperators(publicKeys ...*ecdsa.PublicKey) Option {
+	return func(o *options) {
+		o.operatorKeys = append(o.operatorKeys, publicKeys...)
+	}
+}
+
+// WithTrustedOperatorIds pins operators by their identity id, see WithTrustedOperators
+func WithTrustedOperatorIds(identityIds ...string) Option {
+	return func(o *options) {
+		o.operatorIds = append(o.operatorIds, identityIds...)
+	}
+}
+
+// trustAnchors returns the ids of the pinned operators for vaultId or nil if no operator is pinned.
+// Keys which can not be converted are left out, so they never widen the trust.
+func (o *options) trustAnchors(vaultId string) map[string]bool {
+	if len(o.operatorKeys) == 0 && len(o.operatorIds) == 0 {
+		return nil
+	}
+	anchors := make(map[string]bool, len(o.operatorKeys)+len(o.operatorIds))
+	for _, id := range o.operatorIds {
+		anchors[id] = true
+	}
+	for _, key := range o.operatorKeys {
+		if id, err := helper.GetIdFromPublicKey(key, vaultId); err == nil {
+			anchors[id] = true
+		}
+	}
+	return anchors
+}
+
+// WithValueCache caches decrypted values read by GetIdentityValueById and GetIdentityValueByName of every
+// ProtectedApi for ttl, so reading them again needs no request. Expired values are fetched again but only
+// decrypted if their passframe or update time changed. At most maxEntries values are kept, 0 means no limit.
+// Evicted secrets are overwritten in memory on a best-effort basis, copies handed out as string are not.
+func WithValueCache(ttl time.Duration, maxEntries int) Option {
+	return func(o *options) {
+		o.cacheTTL = ttl
+		o.cacheSize = maxEntries
+	}
+}
+
+func (o *options) valueCache() *valueCache {
+	if o.cacheTTL <= 0 {
+		return nil
+	}
+	return newValueCache(o.cacheTTL, o.cacheSize)
+}
+
+// WithWatchInterval sets how often Watch polls for changes if no WatchTransport is used.
+// Default is DefaultWatchInterval.
+func WithWatchInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.watchEvery = interval
+	}
+}
+
+func (o *options) watchInterval() time.Duration {
+	if o.watchEvery <= 0 {
+		return DefaultWatchInterval
+	}
+	return o.watchEvery
+}
+
+// WithWatchTransport lets Watch receive changes through transport instead of polling.
+// If transport returns ErrWatchUnsupported, Watch falls back to polling.
+func WithWatchTransport(transport WatchTransport) Option {
+	return func(o *options) {
+		o.watcher = transport
+	}
+}
+
+// WithBinaryChunkSize sets the size in bytes above which AddBinaryValue splits the data into chunks.
+// Default is DefaultBinaryChunkSize.
+func WithBinaryChunkSize(size int) Option {
+	return func(o *options) {
+		o.chunkSize = size
+	}
+}
+
+func (o *options) binaryChunkSize() int {
+	if o.chunkSize <= 0 {
+		return DefaultBinaryChunkSize
+	}
+	return o.chunkSize
+}
+
+// httpClient returns a copy of httpClient with the configured timeout and headers
+func (o *options) httpClient(httpClient *http.Client) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	h := *httpClient
+	if o.timeout > 0 {
+		h.Timeout = o.timeout
+	}
+	if o.userAgent != "" || len(o.headers) > 0 {
+		wrapped := h.Transport
+		if wrapped == nil {
+			wrapped = http.DefaultTransport
+		}
+		h.Transport = &headerTransport{wrapped: wrapped, userAgent: o.userAgent, headers: o.headers}
+	}
+	return &h
+}
+
+// graphqlClient returns the graphql client for endpoint with retry, logging and tracing applied
+func (o *options) graphqlClient(endpoint string, httpClient *http.Client) graphql.Client {
+	h := *httpClient
+	if h.Transport == nil {
+		h.Transport = http.DefaultTransport
+	}
+	h.Transport = &statusTransport{wrapped: h.Transport}
+	var client graphql.Client = &errorClient{wrapped: graphql.NewClient(endpoint, &h)}
+	if o.retryPolicy != nil {
+		client = &retryClient{wrapped: client, policy: *o.retryPolicy}
+	}
+	if o.logger != nil || o.tracer != nil {
+		client = &instrumentedClient{wrapped: client, logger: o.logger, tracer: o.tracer}
+	}
+	return client
+}
+
+type headerTransport struct {
+	wrapped   http.RoundTripper
+	userAgent string
+	headers   http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header[k] = append([]string(nil), v...)
+	}
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	return t.wrapped.RoundTrip(req)
+}
+
+type instrumentedClient struct {
+	wrapped graphql.Client
+	logger  *slog.Logger
+	tracer  Tracer
+}
+
+func (c *instrumentedClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	end := func(error) {}
+	if c.tracer != nil {
+		ctx, end = c.tracer.StartOperation(ctx, req.OpName)
+	}
+	start := time.Now()
+	err := c.wrapped.MakeRequest(ctx, req, resp)
+	end(err)
+	if c.logger != nil {
+		if err != nil {
+			c.logger.LogAttrs(ctx, slog.LevelWarn, "graphql operation failed", slog.String("operation", req.OpName), slog.Duration("duration", time.Since(start)), slog.Any("error", err))
+		} else {
+			c.logger.LogAttrs(ctx, slog.LevelDebug, "graphql operation", slog.String("operation", req.OpName), slog.Duration("duration", time.Since(start)))
+		}
+	}
+	return err
+}
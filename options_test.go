@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+type recordingTracer struct {
+	mu         sync.Mutex
+	operations []string
+}
+
+func (t *recordingTracer) StartOperation(ctx context.Context, opName string) (context.Context, func(err error)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.operations = append(t.operations, opName)
+	return ctx, func(error) {}
+}
+
+func TestOptionsApplyToProtectedApi(t *testing.T) {
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"createVault":"vault","getVault":{"id":"vault","name":"test"}}}`))
+	}))
+	defer server.Close()
+
+	tracer := &recordingTracer{}
+	a := NewApi(server.URL, server.Client(),
+		WithUserAgent("cryptvault-test"),
+		WithExtraHeaders(http.Header{"X-Team": []string{"payments"}}),
+		WithTracer(tracer),
+	)
+	key, _, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.NewVaultByPublicKey("test", "token", &key.PublicKey); err != nil {
+		t.Fatalf("NewVaultByPublicKey() error = %v", err)
+	}
+	if _, err := a.GetProtectedApi(key, "vault").GetVault(); err != nil {
+		t.Fatalf("GetVault() error = %v", err)
+	}
+
+	if len(headers) != 2 {
+		t.Fatalf("got %d requests, want 2", len(headers))
+	}
+	for i, h := range headers {
+		if h.Get("User-Agent") != "cryptvault-test" || h.Get("X-Team") != "payments" {
+			t.Errorf("request %d has headers %v, want user agent and extra header", i, h)
+		}
+	}
+	if headers[1].Get("Authorization") == "" {
+		t.Error("protected request without Authorization header")
+	}
+	if len(tracer.operations) != 2 || tracer.operations[1] != "getVault" {
+		t.Errorf("traced operations = %v, want createNewVault and getVault", tracer.operations)
+	}
+}
@@ -1,7 +1,7 @@
 package api
 
 import (
-	"crypto/ecdsa"
+	"time"
 
 	"github.com/Khan/genqlient/graphql"
 )
@@ -10,10 +10,24 @@ var _ ProtectedApiHandler = (*ProtectedApi)(nil)
 
 type ProtectedApi struct {
 	vaultId  string
-	authKey  *ecdsa.PrivateKey
+	signer   SignerDecrypter
 	api      *Api
 	endpoint string
 	client   graphql.Client
+	// trustAnchors holds the ids of the pinned operators, nil if every operator is trusted, see WithTrustedOperators
+	trustAnchors map[string]bool
+	// cache keeps decrypted values, nil if disabled, see WithValueCache
+	cache *valueCache
+	// autoShare shares values after rights of an identity changed, see WithAutoShareValues
+	autoShare bool
+	// valueHistory is the number of prior versions kept per value, see WithValueHistory
+	valueHistory int
+	// watchInterval is the poll interval of Watch, see WithWatchInterval
+	watchInterval time.Duration
+	// watchTransport delivers changes to Watch instead of polling, nil if not set, see WithWatchTransport
+	watchTransport WatchTransport
+	// binaryChunkSize is the size above which binary values are split, see WithBinaryChunkSize
+	binaryChunkSize int
 }
 
 type ProtectedApiHandler interface {
@@ -22,3 +36,12 @@ type ProtectedApiHandler interface {
 	ValueHandler
 	RightHandler
 }
+
+// isTrustAnchor reports whether a creator chain may end at identity id.
+// Without pinned operators every identity flagged as operator by the server is a trust anchor.
+func (a *ProtectedApi) isTrustAnchor(id string, isOperator bool) bool {
+	if a.trustAnchors == nil {
+		return isOperator
+	}
+	return a.trustAnchors[id]
+}
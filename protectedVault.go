@@ -6,27 +6,50 @@ var _ ProtectedVaultHandler = (*ProtectedApi)(nil)
 
 type ProtectedVaultHandler interface {
 	GetVault() (*getVaultGetVault, error)
+	GetVaultContext(ctx context.Context) (*getVaultGetVault, error)
 	UpdateVault(name string) (*updateVaultUpdateVaultUpdateVaultPayloadAffectedVault, error)
+	UpdateVaultContext(ctx context.Context, name string) (*updateVaultUpdateVaultUpdateVaultPayloadAffectedVault, error)
 	DeleteVault(id string) error
+	DeleteVaultContext(ctx context.Context, id string) error
+	VerifyVaultIntegrity() (*IntegrityReport, error)
+	VerifyVaultIntegrityContext(ctx context.Context) (*IntegrityReport, error)
 }
 
 func (a *ProtectedApi) GetVault() (*getVaultGetVault, error) {
-	resp, err := getVault(context.Background(), a.client, a.vaultId)
+	return a.GetVaultContext(context.Background())
+}
+
+func (a *ProtectedApi) GetVaultContext(ctx context.Context) (*getVaultGetVault, error) {
+	resp, err := getVault(ctx, a.client, a.vaultId)
 	if err != nil {
 		return nil, err
 	}
+	if resp.GetVault == nil {
+		return nil, ErrVaultNotFound
+	}
 	return resp.GetVault, nil
 }
 
 func (a *ProtectedApi) UpdateVault(name string) (*updateVaultUpdateVaultUpdateVaultPayloadAffectedVault, error) {
-	resp, err := updateVault(context.Background(), a.client, name)
+	return a.UpdateVaultContext(context.Background(), name)
+}
+
+func (a *ProtectedApi) UpdateVaultContext(ctx context.Context, name string) (*updateVaultUpdateVaultUpdateVaultPayloadAffectedVault, error) {
+	resp, err := updateVault(ctx, a.client, name)
 	if err != nil {
 		return nil, err
 	}
+	if resp.UpdateVault == nil || len(resp.UpdateVault.Affected) == 0 {
+		return nil, ErrVaultNotFound
+	}
 	return resp.UpdateVault.Affected[0], nil
 }
 
 func (a *ProtectedApi) DeleteVault(id string) error {
-	_, err := deleteVault(context.Background(), a.client, id)
+	return a.DeleteVaultContext(context.Background(), id)
+}
+
+func (a *ProtectedApi) DeleteVaultContext(ctx context.Context, id string) error {
+	_, err := deleteVault(ctx, a.client, id)
 	return err
 }
@@ -0,0 +1,60 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cryptvault-cloud/api"
+	"github.com/cryptvault-cloud/api/vaulttest"
+)
+
+func TestTrustedOperators(t *testing.T) {
+	server := vaulttest.NewServer()
+	t.Cleanup(server.Close)
+	private, public, vaultId, err := api.NewApi(server.URL, http.DefaultClient).NewVault("test", "token")
+	if err != nil {
+		t.Fatalf("NewVault() error = %v", err)
+	}
+	a := api.NewApi(server.URL, http.DefaultClient, api.WithTrustedOperators(public))
+	operator := a.GetProtectedApi(private, vaultId)
+	ctx := context.Background()
+
+	// an operator the client does not know about, f.e. minted by a malicious backend
+	second, err := operator.CreateIdentity("second", rightInputs(t, "(rwd)VALUES.>", "(rwd)IDENTITY.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	if err := server.Handler.SetOperator(second.IdentityId, true); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := a.GetProtectedApi(second.PrivateKey, vaultId).CreateIdentity("reader", rightInputs(t, "(r)VALUES.a.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+
+	var untrusted *api.UntrustedIdentitiesError
+	if _, err := operator.AddValue("VALUES.a.b", "secret", api.ValueTypeString); !errors.As(err, &untrusted) {
+		t.Fatalf("AddValue() error = %v, want *UntrustedIdentitiesError", err)
+	}
+	got := make(map[string]bool)
+	for _, v := range untrusted.Identities {
+		got[v.IdentityId] = true
+	}
+	if len(got) != 2 || !got[second.IdentityId] || !got[reader.IdentityId] {
+		t.Errorf("AddValue() untrusted identities = %+v, want %s and %s", untrusted.Identities, second.IdentityId, reader.IdentityId)
+	}
+
+	report, err := operator.VerifyVaultIntegrityContext(ctx)
+	if err != nil {
+		t.Fatalf("VerifyVaultIntegrity() error = %v", err)
+	}
+	kinds := make(map[string]api.IntegrityIssueKind)
+	for _, v := range report.Issues {
+		kinds[v.IdentityId] = v.Kind
+	}
+	if kinds[second.IdentityId] != api.IntegrityUntrustedOperator || kinds[reader.IdentityId] != api.IntegrityBrokenChain {
+		t.Errorf("VerifyVaultIntegrity() issues = %+v, want untrusted operator and broken chain", report.Issues)
+	}
+}
@@ -11,22 +11,32 @@ var _ VaultHandler = (*Api)(nil)
 
 type VaultHandler interface {
 	NewVaultByPublicKey(name, token string, publicKey *ecdsa.PublicKey) (vaultID string, err error)
+	NewVaultByPublicKeyContext(ctx context.Context, name, token string, publicKey *ecdsa.PublicKey) (vaultID string, err error)
 	NewVault(name, token string) (private *ecdsa.PrivateKey, public *ecdsa.PublicKey, vaultID string, err error)
+	NewVaultContext(ctx context.Context, name, token string) (private *ecdsa.PrivateKey, public *ecdsa.PublicKey, vaultID string, err error)
 }
 
 func (a *Api) NewVaultByPublicKey(name, token string, publicKey *ecdsa.PublicKey) (vaultID string, err error) {
+	return a.NewVaultByPublicKeyContext(context.Background(), name, token, publicKey)
+}
+
+func (a *Api) NewVaultByPublicKeyContext(ctx context.Context, name, token string, publicKey *ecdsa.PublicKey) (vaultID string, err error) {
 
 	pubbase64, err := helper.NewBase64PublicPem(publicKey)
 	if err != nil {
 		return
 	}
 
-	resp, err := createNewVault(context.Background(), a.client, name, pubbase64, token)
+	resp, err := createNewVault(ctx, a.client, name, pubbase64, token)
 	vaultID = resp.CreateVault
 	return
 }
 
 func (a *Api) NewVault(name, token string) (private *ecdsa.PrivateKey, public *ecdsa.PublicKey, vaultID string, err error) {
+	return a.NewVaultContext(context.Background(), name, token)
+}
+
+func (a *Api) NewVaultContext(ctx context.Context, name, token string) (private *ecdsa.PrivateKey, public *ecdsa.PublicKey, vaultID string, err error) {
 	private, public, err = a.GetNewIdentityKeyPair()
 	if err != nil {
 		return
@@ -36,7 +46,7 @@ func (a *Api) NewVault(name, token string) (private *ecdsa.PrivateKey, public *e
 		return
 	}
 
-	resp, err := createNewVault(context.Background(), a.client, name, pubbase64, token)
+	resp, err := createNewVault(ctx, a.client, name, pubbase64, token)
 	vaultID = resp.CreateVault
 	return
 }
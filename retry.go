@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// RetryPolicy describes how often and how fast failed graphql operations are retried.
+// Queries are retried on network errors and on the http status codes 429, 502, 503 and 504.
+// Errors returned by the graphql endpoint itself are never retried.
+// Mutations are only retried if their operation name is listed at SafeMutations.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one
+	MaxAttempts int
+	// InitialBackoff is the wait time before the first retry, it doubles for every further retry
+	InitialBackoff time.Duration
+	// MaxBackoff limits the wait time between two attempts, also if the server asks for more by Retry-After
+	MaxBackoff time.Duration
+	// Jitter is the fraction (0 to 1) of the backoff which is randomized
+	Jitter float64
+	// SafeMutations lists operation names of mutations which can be executed more than once, f.e.: "updateVault"
+	SafeMutations []string
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with 4 attempts and a backoff between 200ms and 5s
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Jitter:         0.5,
+	}
+}
+
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > 0 {
+		if p.MaxBackoff > 0 && httpErr.RetryAfter > p.MaxBackoff {
+			return p.MaxBackoff
+		}
+		return httpErr.RetryAfter
+	}
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			backoff = p.MaxBackoff
+			break
+		}
+	}
+	if p.Jitter > 0 && backoff > 0 {
+		jitter := time.Duration(p.Jitter * float64(backoff))
+		backoff = backoff - jitter + time.Duration(rand.Int63n(int64(jitter)+1))
+	}
+	return backoff
+}
+
+func (p RetryPolicy) canRetry(req *graphql.Request) bool {
+	return isQuery(req) || slices.Contains(p.SafeMutations, req.OpName)
+}
+
+type retryClient struct {
+	wrapped graphql.Client
+	policy  RetryPolicy
+}
+
+func (c *retryClient) MakeRequest(ctx context.Context, req *graphql.Request, resp *graphql.Response) error {
+	attempt := 1
+	for {
+		resp.Errors = nil
+		err := c.wrapped.MakeRequest(ctx, req, resp)
+		if err == nil || attempt >= c.policy.MaxAttempts || !c.policy.canRetry(req) || !isRetryable(ctx, err) {
+			return err
+		}
+		timer := time.NewTimer(c.policy.backoff(attempt, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+		attempt++
+	}
+}
+
+func isQuery(req *graphql.Request) bool {
+	return strings.HasPrefix(strings.TrimSpace(req.Query), "query")
+}
+
+// isRetryable reports whether err is a network error or a temporary http error.
+// Errors returned by the graphql endpoint and cancellation of ctx are not retried.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+	tests := []struct {
+		name         string
+		status       int
+		mutation     bool
+		safeMutation bool
+		wantAttempts int
+		wantErr      bool
+	}{
+		{name: "query retried after bad gateway", status: http.StatusBadGateway, wantAttempts: 3},
+		{name: "query not retried after bad request", status: http.StatusBadRequest, wantAttempts: 1, wantErr: true},
+		{name: "mutation not retried", status: http.StatusBadGateway, mutation: true, wantAttempts: 1, wantErr: true},
+		{name: "safe mutation retried", status: http.StatusBadGateway, mutation: true, safeMutation: true, wantAttempts: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if attempts < 3 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"data":{"getVault":{"id":"vault"},"updateVault":{"affected":[{"id":"vault"}]}}}`))
+			}))
+			defer server.Close()
+
+			p := policy
+			if tt.safeMutation {
+				p.SafeMutations = []string{"updateVault"}
+			}
+			key, _, err := helper.GenerateNewKeyPair()
+			if err != nil {
+				t.Fatal(err)
+			}
+			protected := NewApi(server.URL, server.Client(), WithRetryPolicy(p)).GetProtectedApi(key, "vault")
+			if tt.mutation {
+				_, err = protected.UpdateVault("name")
+			} else {
+				_, err = protected.GetVault()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var httpErr *HTTPError
+			if err != nil && !errors.As(err, &httpErr) {
+				t.Errorf("error = %v, want *HTTPError", err)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyLimitsRetryAfter(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Second}
+	tests := []struct {
+		retryAfter time.Duration
+		want       time.Duration
+	}{
+		{retryAfter: 2 * time.Second, want: 2 * time.Second},
+		{retryAfter: 3 * time.Hour, want: 5 * time.Second},
+	}
+	for _, tt := range tests {
+		err := &HTTPError{StatusCode: http.StatusServiceUnavailable, RetryAfter: tt.retryAfter}
+		if got := policy.backoff(1, err); got != tt.want {
+			t.Errorf("backoff() with Retry-After %v = %v, want %v", tt.retryAfter, got, tt.want)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := parseRetryAfter("3", now); got != 3*time.Second {
+		t.Errorf("parseRetryAfter(3) = %v, want 3s", got)
+	}
+	if got := parseRetryAfter(now.Add(time.Minute).Format(http.TimeFormat), now); got != time.Minute {
+		t.Errorf("parseRetryAfter(date) = %v, want 1m", got)
+	}
+	if got := parseRetryAfter("soon", now); got != 0 {
+		t.Errorf("parseRetryAfter(soon) = %v, want 0", got)
+	}
+}
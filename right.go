@@ -6,11 +6,17 @@ var _ RightHandler = (*ProtectedApi)(nil)
 
 type RightHandler interface {
 	DeleteRight(rightId, identityId string) (int, error)
+	DeleteRightContext(ctx context.Context, rightId, identityId string) (int, error)
 	AddRights(rights []*RightInput, identityId string) ([]string, error)
+	AddRightsContext(ctx context.Context, rights []*RightInput, identityId string) ([]string, error)
 }
 
 func (a *ProtectedApi) DeleteRight(rightId, identityId string) (int, error) {
-	resp, err := deleteRight(context.Background(), a.client, rightId, identityId)
+	return a.DeleteRightContext(context.Background(), rightId, identityId)
+}
+
+func (a *ProtectedApi) DeleteRightContext(ctx context.Context, rightId, identityId string) (int, error) {
+	resp, err := deleteRight(ctx, a.client, rightId, identityId)
 	if err != nil {
 		return -1, err
 	}
@@ -18,12 +24,24 @@ func (a *ProtectedApi) DeleteRight(rightId, identityId string) (int, error) {
 }
 
 func (a *ProtectedApi) AddRights(rights []*RightInput, identityId string) ([]string, error) {
+	return a.AddRightsContext(context.Background(), rights, identityId)
+}
+
+func (a *ProtectedApi) AddRightsContext(ctx context.Context, rights []*RightInput, identityId string) ([]string, error) {
+	rightIds, err := a.addRights(ctx, rights, identityId)
+	if err != nil {
+		return nil, err
+	}
+	return rightIds, a.autoShareValues(ctx, identityId)
+}
+
+func (a *ProtectedApi) addRights(ctx context.Context, rights []*RightInput, identityId string) ([]string, error) {
 
 	for _, v := range rights {
 		v.IdentityID = identityId
 	}
 
-	rightResp, err := addRight(context.Background(), a.client, rights)
+	rightResp, err := addRight(ctx, a.client, rights)
 	rightIds := make([]string, 0)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"crypto/ecdsa"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+// Signer signs the JWTs of an identity. Implementations can keep the private key outside
+// of the process, f.e.: in a HSM, a KMS or an agent process.
+type Signer interface {
+	// PublicKey returns the public key of the identity
+	PublicKey() *ecdsa.PublicKey
+	// SignJWT returns a JWT as created by helper.SignJWT to authenticate at vaultId
+	SignJWT(vaultId string) (string, error)
+	// SignCreatorJWT returns a JWT as created by helper.SignCreatorJWT to verify the creation of childTokenId
+	SignCreatorJWT(childTokenId, vaultId string) (string, error)
+}
+
+// Decrypter decrypts passframes encrypted for the public key of an identity
+type Decrypter interface {
+	Decrypt(passframe string) ([]byte, error)
+}
+
+// SignerDecrypter holds all private key operations a ProtectedApi needs
+type SignerDecrypter interface {
+	Signer
+	Decrypter
+}
+
+var _ SignerDecrypter = (*PrivateKeySigner)(nil)
+
+// PrivateKeySigner is the in memory SignerDecrypter used by GetProtectedApi
+type PrivateKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key}
+}
+
+func (s *PrivateKeySigner) PublicKey() *ecdsa.PublicKey {
+	return &s.key.PublicKey
+}
+
+func (s *PrivateKeySigner) SignJWT(vaultId string) (string, error) {
+	return helper.SignJWT(s.key, vaultId)
+}
+
+func (s *PrivateKeySigner) SignCreatorJWT(childTokenId, vaultId string) (string, error) {
+	return helper.SignCreatorJWT(s.key, childTokenId, vaultId)
+}
+
+func (s *PrivateKeySigner) Decrypt(passframe string) ([]byte, error) {
+	return helper.Decrypt(s.key, passframe)
+}
@@ -0,0 +1,33 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cryptvault-cloud/api"
+	"github.com/cryptvault-cloud/api/vaulttest"
+)
+
+func TestGetProtectedApiWithSigner(t *testing.T) {
+	server := vaulttest.NewServer()
+	defer server.Close()
+
+	a := api.NewApi(server.URL, http.DefaultClient)
+	private, _, vaultId, err := a.NewVault("test", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := &api.CountingSigner{PrivateKeySigner: api.NewPrivateKeySigner(private)}
+	protected := a.GetProtectedApiWithSigner(signer, vaultId)
+
+	if _, err := protected.AddValue("VALUES.signer", "secret", api.ValueTypeString); err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	value, err := protected.GetIdentityValueByName("VALUES.signer")
+	if err != nil {
+		t.Fatalf("GetIdentityValueByName() error = %v", err)
+	}
+	if value.Value != "secret" || signer.Decrypted != 1 {
+		t.Errorf("GetIdentityValueByName() = %s with %d decryptions, want secret with 1", value.Value, signer.Decrypted)
+	}
+}
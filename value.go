@@ -2,9 +2,12 @@ package api
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cryptvault-cloud/helper"
@@ -14,24 +17,78 @@ var _ ValueHandler = (*ProtectedApi)(nil)
 
 type ValueHandler interface {
 	DeleteIdentityValue(id *string) (int, error)
+	DeleteIdentityValueContext(ctx context.Context, id *string) (int, error)
 	AddValue(key, value string, valueType ValueType) (string, error)
+	AddValueContext(ctx context.Context, key, value string, valueType ValueType) (string, error)
 	DeleteValue(id string) error
+	DeleteValueContext(ctx context.Context, id string) error
 	GetValueById(id string) (*getValueGetValue, error)
+	GetValueByIdContext(ctx context.Context, id string) (*getValueGetValue, error)
 	GetIdentityValueById(id string) (*IdentityValue, error)
+	GetIdentityValueByIdContext(ctx context.Context, id string) (*IdentityValue, error)
 	GetIdentityValueByName(name string) (*IdentityValue, error)
+	GetIdentityValueByNameContext(ctx context.Context, name string) (*IdentityValue, error)
+	GetIdentityValuesByPattern(pattern string) (map[string]*IdentityValue, error)
+	GetIdentityValuesByPatternContext(ctx context.Context, pattern string) (map[string]*IdentityValue, error)
+	LoadConfig(prefix string, target any) (*ConfigReport, error)
+	LoadConfigContext(ctx context.Context, prefix string, target any) (*ConfigReport, error)
+	AddBinaryValue(name string, data []byte) (string, error)
+	AddBinaryValueContext(ctx context.Context, name string, data []byte) (string, error)
+	GetBinaryValue(name string) ([]byte, error)
+	GetBinaryValueContext(ctx context.Context, name string) ([]byte, error)
+	DeleteBinaryValue(id string) error
+	DeleteBinaryValueContext(ctx context.Context, id string) error
+	AddCertificateValue(name string, pemBundle []byte) (string, error)
+	AddCertificateValueContext(ctx context.Context, name string, pemBundle []byte) (string, error)
+	GetCertificateValue(name string) (*CertificateBundle, error)
+	GetCertificateValueContext(ctx context.Context, name string) (*CertificateBundle, error)
+	AddKeyPairValue(name string, privateKeyPEM []byte) (string, error)
+	AddKeyPairValueContext(ctx context.Context, name string, privateKeyPEM []byte) (string, error)
+	GetKeyPairValue(name string) (*KeyPair, error)
+	GetKeyPairValueContext(ctx context.Context, name string) (*KeyPair, error)
 	GetValueByName(name string) (*getValueByNameQueryValueValueQueryResultDataValue, error)
+	GetValueByNameContext(ctx context.Context, name string) (*getValueByNameQueryValueValueQueryResultDataValue, error)
 	UpdateValue(id, key, value string, valueType ValueType) (string, error)
+	UpdateValueContext(ctx context.Context, id, key, value string, valueType ValueType) (string, error)
+	RotateValue(id, value string) error
+	RotateValueContext(ctx context.Context, id, value string) error
+	ListValueVersions(id string) ([]*ValueVersion, error)
+	ListValueVersionsContext(ctx context.Context, id string) ([]*ValueVersion, error)
+	GetIdentityValueVersion(id string, version int) (*IdentityValue, error)
+	GetIdentityValueVersionContext(ctx context.Context, id string, version int) (*IdentityValue, error)
+	RestoreValueVersion(id string, version int) (string, error)
+	RestoreValueVersionContext(ctx context.Context, id string, version int) (string, error)
 	SyncValues(identityId string) error
+	SyncValuesContext(ctx context.Context, identityId string) error
 	SyncValue(id string) error
+	SyncValueContext(ctx context.Context, id string) error
 	AddIdentityValue(input IdentityValueInput) (string, error)
+	AddIdentityValueContext(ctx context.Context, input IdentityValueInput) (string, error)
 	GetDecryptedPassframe(value []EncryptenValue) (string, error)
+	InvalidateCachedValue(idOrName string)
+	ClearValueCache()
+	Watch(ctx context.Context, pattern string) (<-chan ValueEvent, error)
+	ExportValues(pattern string, recipient *ecdsa.PublicKey) (*ValueArchive, error)
+	ExportValuesContext(ctx context.Context, pattern string, recipient *ecdsa.PublicKey) (*ValueArchive, error)
+	ImportValues(archive *ValueArchive, opts ImportOptions) ([]*ImportResult, error)
+	ImportValuesContext(ctx context.Context, archive *ValueArchive, opts ImportOptions) ([]*ImportResult, error)
+	ListValues(filter *ValueFiltersInput, order *ValueOrder, page Page) (*ValueList, error)
+	ListValuesContext(ctx context.Context, filter *ValueFiltersInput, order *ValueOrder, page Page) (*ValueList, error)
+	IterateValues(filter *ValueFiltersInput, order *ValueOrder, pageSize int) *ValueIterator
 	GetAllRelatedValues(identityId string) ([]*allRelatedValuesAllRelatedValuesValue, error)
+	GetAllRelatedValuesContext(ctx context.Context, identityId string) ([]*allRelatedValuesAllRelatedValuesValue, error)
 	GetAllRelatedValuesWithIdentityValues(identityId string) ([]*allRelatedValuesWithIdentityValuesAllRelatedValuesValue, error)
+	GetAllRelatedValuesWithIdentityValuesContext(ctx context.Context, identityId string) ([]*allRelatedValuesWithIdentityValuesAllRelatedValuesValue, error)
 	GetAllRelatedValuesWithIdentityValuesAndPassframe(identityId string) ([]*allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue, error)
+	GetAllRelatedValuesWithIdentityValuesAndPassframeContext(ctx context.Context, identityId string) ([]*allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue, error)
 }
 
 func (a *ProtectedApi) DeleteIdentityValue(id *string) (int, error) {
-	resp, err := removeIdentityValue(context.Background(), a.client, id)
+	return a.DeleteIdentityValueContext(context.Background(), id)
+}
+
+func (a *ProtectedApi) DeleteIdentityValueContext(ctx context.Context, id *string) (int, error) {
+	resp, err := removeIdentityValue(ctx, a.client, id)
 	if err != nil {
 		return 0, err
 	}
@@ -39,15 +96,22 @@ func (a *ProtectedApi) DeleteIdentityValue(id *string) (int, error) {
 }
 
 func (a *ProtectedApi) AddValue(key, value string, valueType ValueType) (string, error) {
+	return a.AddValueContext(context.Background(), key, value, valueType)
+}
+
+func (a *ProtectedApi) AddValueContext(ctx context.Context, key, value string, valueType ValueType) (string, error) {
 	if strings.Contains(key, "*") || strings.Contains(key, ">") {
-		return "", errors.New("key can not have wildcard symbols * or >")
+		return "", ErrInvalidValueKey
 	}
-	resp, err := getRelatedIdenties(context.Background(), a.client, key)
+	if err := checkValueContent(value, valueType); err != nil {
+		return "", err
+	}
+	resp, err := getRelatedIdenties(ctx, a.client, key)
 	if err != nil {
 		return "", err
 	}
 
-	pemPubkey, err := helper.NewBase64PublicPem(&a.authKey.PublicKey)
+	pemPubkey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
 	if err != nil {
 		return "", err
 	}
@@ -63,18 +127,28 @@ func (a *ProtectedApi) AddValue(key, value string, valueType ValueType) (string,
 		}
 	}
 	if !hasOwnId {
-		return "", errors.New("sender Identity has not the rights to create value")
+		return "", &PermissionDeniedError{IdentityId: ownId, Target: RightTargetValues, Direction: DirectionsWrite, Resource: key}
+	}
+	if err := a.checkIdentitiesHaveRelatedSignatureChain(resp.GetIdentitiesWithValueAccess()); err != nil {
+		return "", err
 	}
 
 	identityValues := make([]*IdentityValueInput, 0)
 
-	respaddValue, err := addValue(context.Background(), a.client, key, valueType)
+	respaddValue, err := addValue(ctx, a.client, key, valueType)
 	if err != nil {
 		return "", err
 	}
+	if respaddValue.AddValue == nil || len(respaddValue.AddValue.Affected) == 0 {
+		return "", ErrNothingCreated
+	}
 	valueId := respaddValue.AddValue.Affected[0].Id
 	var forLoopErr error = nil
 	for _, v := range resp.IdentitiesWithValueAccess {
+		if err := ctx.Err(); err != nil {
+			forLoopErr = err
+			break
+		}
 		encrpytValue, err := v.PublicKey.Encrypt(value)
 		if err != nil {
 			forLoopErr = err
@@ -88,13 +162,13 @@ func (a *ProtectedApi) AddValue(key, value string, valueType ValueType) (string,
 	}
 
 	if forLoopErr != nil {
-		err := a.DeleteValue(valueId)
+		err := a.DeleteValueContext(context.WithoutCancel(ctx), valueId)
 		return "", errors.Join(err, forLoopErr)
 	}
 
-	_, err = addIdentityValue(context.Background(), a.client, identityValues)
+	_, err = addIdentityValue(ctx, a.client, identityValues)
 	if err != nil {
-		err2 := a.DeleteValue(valueId)
+		err2 := a.DeleteValueContext(context.WithoutCancel(ctx), valueId)
 		return "", errors.Join(err, err2)
 	}
 	return valueId, err
@@ -102,36 +176,67 @@ func (a *ProtectedApi) AddValue(key, value string, valueType ValueType) (string,
 }
 
 func (a *ProtectedApi) DeleteValue(id string) error {
-	_, err := deleteValue(context.Background(), a.client, id)
+	return a.DeleteValueContext(context.Background(), id)
+}
+
+func (a *ProtectedApi) DeleteValueContext(ctx context.Context, id string) error {
+	// invalidate after the value was deleted, so no concurrent read caches it again
+	defer a.InvalidateCachedValue(id)
+	if a.valueHistory <= 0 {
+		_, err := deleteValue(ctx, a.client, id)
+		return err
+	}
+	value, err := a.GetValueByIdContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, err := deleteValue(ctx, a.client, id); err != nil {
+		return err
+	}
+	_, err = deleteValueVersions(ctx, a.client, value.Name+valueVersionSegment)
 	return err
 }
 
 func (a *ProtectedApi) GetValueById(id string) (*getValueGetValue, error) {
-	resp, err := getValue(context.Background(), a.client, id)
-	return resp.GetValue, err
+	return a.GetValueByIdContext(context.Background(), id)
 }
 
-func (a *ProtectedApi) GetIdentityValueById(id string) (*IdentityValue, error) {
-	resp, err := getValue(context.Background(), a.client, id)
+func (a *ProtectedApi) GetValueByIdContext(ctx context.Context, id string) (*getValueGetValue, error) {
+	resp, err := getValue(ctx, a.client, id)
 	if err != nil {
 		return nil, err
 	}
-	values := make([]EncryptenValue, 0)
-	for _, v := range resp.GetValue.Value {
-		values = append(values, v)
+	if resp.GetValue == nil {
+		return nil, ErrValueNotFound
+	}
+	return resp.GetValue, nil
+}
+
+func (a *ProtectedApi) GetIdentityValueById(id string) (*IdentityValue, error) {
+	return a.GetIdentityValueByIdContext(context.Background(), id)
+}
+
+func (a *ProtectedApi) GetIdentityValueByIdContext(ctx context.Context, id string) (*IdentityValue, error) {
+	if a.cache != nil {
+		if cached, ok := a.cache.get(id, ""); ok {
+			return cached, nil
+		}
 	}
-	password, err := a.GetDecryptedPassframe(values)
+	value, err := a.GetValueByIdContext(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return &IdentityValue{
-		Name:      resp.GetValue.Name,
-		Type:      resp.GetValue.Type,
-		Id:        resp.GetValue.Id,
-		CreatedAt: resp.GetValue.CreatedAt,
-		UpdatedAt: resp.GetValue.UpdatedAt,
-		Value:     password,
-	}, nil
+	values := make([]EncryptenValue, 0)
+	for _, v := range value.Value {
+		values = append(values, v)
+	}
+	return a.decryptIdentityValue(&IdentityValue{
+		Name:      value.Name,
+		Type:      value.Type,
+		Id:        value.Id,
+		CreatedAt: value.CreatedAt,
+		UpdatedAt: value.UpdatedAt,
+	}, values)
 }
 
 type IdentityValue struct {
@@ -144,7 +249,16 @@ type IdentityValue struct {
 }
 
 func (a *ProtectedApi) GetIdentityValueByName(name string) (*IdentityValue, error) {
-	valueResp, err := a.GetValueByName(name)
+	return a.GetIdentityValueByNameContext(context.Background(), name)
+}
+
+func (a *ProtectedApi) GetIdentityValueByNameContext(ctx context.Context, name string) (*IdentityValue, error) {
+	if a.cache != nil {
+		if cached, ok := a.cache.get("", name); ok {
+			return cached, nil
+		}
+	}
+	valueResp, err := a.GetValueByNameContext(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -153,41 +267,174 @@ func (a *ProtectedApi) GetIdentityValueByName(name string) (*IdentityValue, erro
 	for _, v := range valueResp.GetValue() {
 		values = append(values, v)
 	}
-	password, err := a.GetDecryptedPassframe(values)
-	if err != nil {
-		return nil, err
-	}
-	return &IdentityValue{
+	return a.decryptIdentityValue(&IdentityValue{
 		Name:      valueResp.Name,
 		Type:      valueResp.Type,
 		Id:        valueResp.Id,
 		CreatedAt: valueResp.CreatedAt,
 		UpdatedAt: valueResp.UpdatedAt,
-		Value:     password,
-	}, nil
+	}, values)
+}
+
+func (a *ProtectedApi) GetIdentityValuesByPattern(pattern string) (map[string]*IdentityValue, error) {
+	return a.GetIdentityValuesByPatternContext(context.Background(), pattern)
+}
+
+// GetIdentityValuesByPatternContext returns all values matching pattern, f.e.: VALUES.prod.payments.>, decrypted and
+// keyed by name. It accepts the * and > wildcards of rights and loads all values with a single query, prior
+// versions kept by WithValueHistory and chunks of binary values are left out. It fails with an
+// IdentityValueMissingError if a matching value is not shared with the calling identity.
+func (a *ProtectedApi) GetIdentityValuesByPatternContext(ctx context.Context, pattern string) (map[string]*IdentityValue, error) {
+	if err := checkValueNamePattern(pattern); err != nil {
+		return nil, err
+	}
+	return a.identityValuesByPattern(ctx, pattern, false)
+}
+
+// identityValuesByPattern implements GetIdentityValuesByPattern, companion values are only included if companions is set
+func (a *ProtectedApi) identityValuesByPattern(ctx context.Context, pattern string, companions bool) (map[string]*IdentityValue, error) {
+	pemKey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	identityId, err := pemKey.GetIdentityId(a.vaultId)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := allRelatedValuesWithIdentityValuesAndSecret(ctx, a.client, identityId)
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]*allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue, 0)
+	for _, v := range resp.AllRelatedValues {
+		if matchValueName(pattern, v.Name) && (companions || !isCompanionValue(v.Name)) {
+			matched = append(matched, v)
+		}
+	}
+
+	results := make([]*IdentityValue, len(matched))
+	errs := make([]error, len(matched))
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i, v := range matched {
+		wg.Add(1)
+		go func(i int, v *allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			values := make([]EncryptenValue, 0, len(v.Value))
+			for _, identityValue := range v.Value {
+				values = append(values, identityValue)
+			}
+			results[i], errs[i] = a.decryptIdentityValue(&IdentityValue{
+				Name:      v.Name,
+				Type:      v.Type,
+				Id:        v.Id,
+				CreatedAt: v.CreatedAt,
+				UpdatedAt: v.UpdatedAt,
+			}, values)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("decrypt value %s: %w", v.Name, errs[i])
+			}
+		}(i, v)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*IdentityValue, len(results))
+	for _, v := range results {
+		byName[v.Name] = v
+	}
+	return byName, nil
+}
+
+// decryptIdentityValue sets the decrypted secret of values as Value. With WithValueCache the secret is
+// cached and only decrypted again if the passframe or the update time of the value changed.
+func (a *ProtectedApi) decryptIdentityValue(value *IdentityValue, values []EncryptenValue) (*IdentityValue, error) {
+	pemKey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	identityId, err := pemKey.GetIdentityId(a.vaultId)
+	if err != nil {
+		return nil, err
+	}
+	if a.cache == nil {
+		password, err := a.getDecryptedPassframe(identityId, values)
+		if err != nil {
+			return nil, err
+		}
+		value.Value = password
+		return value, nil
+	}
+	for _, v := range values {
+		if v.GetIdentityID() != identityId {
+			continue
+		}
+		if plaintext, ok := a.cache.reuse(value, v.GetPassframe()); ok {
+			return a.cache.put(value, v.GetPassframe(), plaintext), nil
+		}
+		plaintext, err := a.signer.Decrypt(v.GetPassframe())
+		if err != nil {
+			return nil, err
+		}
+		return a.cache.put(value, v.GetPassframe(), plaintext), nil
+	}
+	return nil, &IdentityValueMissingError{IdentityId: identityId}
+}
+
+// InvalidateCachedValue removes the value with the id or name idOrName from the cache enabled by WithValueCache
+func (a *ProtectedApi) InvalidateCachedValue(idOrName string) {
+	if a.cache != nil {
+		a.cache.invalidate(idOrName)
+	}
+}
+
+// ClearValueCache removes all values from the cache enabled by WithValueCache
+func (a *ProtectedApi) ClearValueCache() {
+	if a.cache != nil {
+		a.cache.clear()
+	}
 }
 
 func (a *ProtectedApi) GetValueByName(name string) (*getValueByNameQueryValueValueQueryResultDataValue, error) {
-	resp, err := getValueByName(context.Background(), a.client, name)
+	return a.GetValueByNameContext(context.Background(), name)
+}
+
+func (a *ProtectedApi) GetValueByNameContext(ctx context.Context, name string) (*getValueByNameQueryValueValueQueryResultDataValue, error) {
+	resp, err := getValueByName(ctx, a.client, name)
 	if err != nil {
 		return nil, err
 	}
 	if len(resp.QueryValue.Data) == 0 {
-		return nil, errors.New("value not Found")
+		return nil, ErrValueNotFound
 	}
 	return resp.QueryValue.Data[0], err
 }
 
 func (a *ProtectedApi) UpdateValue(id, key, value string, valueType ValueType) (string, error) {
+	return a.UpdateValueContext(context.Background(), id, key, value, valueType)
+}
+
+func (a *ProtectedApi) UpdateValueContext(ctx context.Context, id, key, value string, valueType ValueType) (string, error) {
 	if strings.Contains(key, "*") || strings.Contains(key, ">") {
-		return "", errors.New("explizit key can not have wildcard symbols * or >")
+		return "", ErrInvalidValueKey
 	}
-	resp, err := a.GetValueById(id)
+	if err := checkValueContent(value, valueType); err != nil {
+		return "", err
+	}
+	resp, err := a.GetValueByIdContext(ctx, id)
 	if err != nil {
 		return "", err
 	}
 
-	pemPubkey, err := helper.NewBase64PublicPem(&a.authKey.PublicKey)
+	pemPubkey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
 	if err != nil {
 		return "", err
 	}
@@ -203,27 +450,55 @@ func (a *ProtectedApi) UpdateValue(id, key, value string, valueType ValueType) (
 		}
 	}
 	if !hasOwnId {
-		return "", errors.New("sender Identity has not the rights to update value")
+		return "", &PermissionDeniedError{IdentityId: ownId, Target: RightTargetValues, Direction: DirectionsWrite, Resource: resp.Name}
 	}
-
-	respaddValue, err := updateValue(context.Background(), a.client, id, key, valueType)
+	related, err := getRelatedIdenties(ctx, a.client, key)
+	if err != nil {
+		return "", err
+	}
+	if err := a.checkIdentitiesHaveRelatedSignatureChain(related.GetIdentitiesWithValueAccess()); err != nil {
+		return "", err
+	}
+	trusted := make(map[string]*getRelatedIdentiesIdentitiesWithValueAccessIdentity, len(related.IdentitiesWithValueAccess))
+	for _, v := range related.IdentitiesWithValueAccess {
+		trusted[v.Id] = v
+	}
+	versionId, err := a.snapshotValue(ctx, resp)
 	if err != nil {
 		return "", err
 	}
+	// invalidate after the passframes were written, so no concurrent read caches the previous secret again
+	defer a.InvalidateCachedValue(id)
+
+	respaddValue, err := updateValue(ctx, a.client, id, key, valueType)
+	if err == nil && (respaddValue.UpdateValue == nil || len(respaddValue.UpdateValue.Affected) == 0) {
+		err = ErrValueNotFound
+	}
+	if err != nil {
+		return "", a.rollbackValueVersion(ctx, versionId, err)
+	}
 	valueId := respaddValue.UpdateValue.Affected[0].Id
 	var forLoopErr error = nil
 	for _, v := range resp.Value {
-		encrpytValue, err := v.Identity.PublicKey.Encrypt(value)
-		if err != nil {
+		if err := ctx.Err(); err != nil {
 			forLoopErr = errors.Join(err, forLoopErr)
+			break
+		}
+		identity, ok := trusted[v.IdentityID]
+		if !ok {
+			// the identity can not read the value anymore, so it does not get the new secret
+			if _, err := deleteIdentityValue(ctx, a.client, v.Id); err != nil {
+				forLoopErr = errors.Join(err, forLoopErr)
+			}
 			continue
 		}
-		identityId, err := v.Identity.PublicKey.GetIdentityId(a.vaultId)
+		encrpytValue, err := identity.PublicKey.Encrypt(value)
 		if err != nil {
 			forLoopErr = errors.Join(err, forLoopErr)
 			continue
 		}
-		_, err = updateIdentityValue(context.Background(), a.client, v.Id, &IdentityValuePatch{
+		identityId := identity.Id
+		_, err = updateIdentityValue(ctx, a.client, v.Id, &IdentityValuePatch{
 			Passframe:  &encrpytValue,
 			IdentityID: &identityId,
 			ValueID:    &valueId,
@@ -233,6 +508,13 @@ func (a *ProtectedApi) UpdateValue(id, key, value string, valueType ValueType) (
 		}
 
 	}
+	if forLoopErr != nil {
+		return "", a.rollbackUpdateValue(ctx, resp, versionId, forLoopErr)
+	}
+	if key != resp.Name {
+		forLoopErr = errors.Join(forLoopErr, a.renameValueVersions(ctx, resp.Name, key))
+	}
+	forLoopErr = errors.Join(forLoopErr, a.pruneValueVersions(ctx, key))
 
 	if forLoopErr != nil {
 		return "", forLoopErr
@@ -241,17 +523,157 @@ func (a *ProtectedApi) UpdateValue(id, key, value string, valueType ValueType) (
 	return valueId, err
 }
 
+// rollbackUpdateValue restores name, type and passframes of value after writing the new passframes failed with err
+// and deletes the version taken before the update
+func (a *ProtectedApi) rollbackUpdateValue(ctx context.Context, value *getValueGetValue, versionId string, err error) error {
+	// the rollback must not be skipped only because the caller context was cancelled
+	rollbackCtx := context.WithoutCancel(ctx)
+	previous := make([]*IdentityValueInput, 0, len(value.Value))
+	for _, v := range value.Value {
+		previous = append(previous, &IdentityValueInput{ValueID: value.Id, IdentityID: v.IdentityID, Passframe: v.Passframe})
+	}
+	if _, rollbackErr := updateValue(rollbackCtx, a.client, value.Id, value.Name, value.Type); rollbackErr != nil {
+		err = errors.Join(err, fmt.Errorf("rollback: %w", rollbackErr))
+	}
+	if rollbackErr := a.applyIdentityValues(rollbackCtx, value.Id, previous); rollbackErr != nil {
+		err = errors.Join(err, fmt.Errorf("rollback: %w", rollbackErr))
+	}
+	return a.rollbackValueVersion(ctx, versionId, err)
+}
+
+func (a *ProtectedApi) RotateValue(id, value string) error {
+	return a.RotateValueContext(context.Background(), id, value)
+}
+
+// RotateValueContext replaces the secret of value id for all identities which have access to it.
+// The new passframes are encrypted for every identity first and written in one request afterwards.
+// If writing or the verification of the written passframes fails the previous passframes are restored
+// and the returned error wraps ErrValueRotation.
+// With WithValueHistory the replaced secret is kept as version of the value.
+func (a *ProtectedApi) RotateValueContext(ctx context.Context, id, value string) error {
+	current, err := a.GetValueByIdContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := checkValueContent(value, current.Type); err != nil {
+		return err
+	}
+	ownerPubKey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
+	if err != nil {
+		return err
+	}
+	ownerId, err := ownerPubKey.GetIdentityId(a.vaultId)
+	if err != nil {
+		return err
+	}
+	resp, err := getRelatedIdenties(ctx, a.client, current.Name)
+	if err != nil {
+		return err
+	}
+	hasOwnId := helper.Includes(resp.IdentitiesWithValueAccess, func(identity *getRelatedIdentiesIdentitiesWithValueAccessIdentity) bool {
+		return identity.Id == ownerId
+	})
+	if !hasOwnId {
+		return &PermissionDeniedError{IdentityId: ownerId, Target: RightTargetValues, Direction: DirectionsWrite, Resource: current.Name}
+	}
+	if err := a.checkIdentitiesHaveRelatedSignatureChain(resp.GetIdentitiesWithValueAccess()); err != nil {
+		return err
+	}
+
+	staged := make([]*IdentityValueInput, 0, len(resp.IdentitiesWithValueAccess))
+	for _, identity := range resp.IdentitiesWithValueAccess {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		passframe, err := identity.PublicKey.Encrypt(value)
+		if err != nil {
+			return fmt.Errorf("encrypt for identity %s: %w", identity.Id, err)
+		}
+		staged = append(staged, &IdentityValueInput{ValueID: id, IdentityID: identity.Id, Passframe: passframe})
+	}
+	previous := make([]*IdentityValueInput, 0, len(current.Value))
+	for _, v := range current.Value {
+		previous = append(previous, &IdentityValueInput{ValueID: id, IdentityID: v.IdentityID, Passframe: v.Passframe})
+	}
+
+	versionId, err := a.snapshotValue(ctx, current)
+	if err != nil {
+		return err
+	}
+	defer a.InvalidateCachedValue(id)
+
+	err = a.applyIdentityValues(ctx, id, staged)
+	if err == nil {
+		err = a.verifyRotatedValue(ctx, id, ownerId, value, staged)
+	}
+	if err != nil {
+		rollbackCtx := context.WithoutCancel(ctx)
+		if rollbackErr := a.applyIdentityValues(rollbackCtx, id, previous); rollbackErr != nil {
+			err = errors.Join(err, fmt.Errorf("rollback: %w", rollbackErr))
+		}
+		return fmt.Errorf("%w: %w", ErrValueRotation, a.rollbackValueVersion(ctx, versionId, err))
+	}
+	return a.pruneValueVersions(ctx, current.Name)
+}
+
+// applyIdentityValues replaces all passframes of value id with passframes in one request
+func (a *ProtectedApi) applyIdentityValues(ctx context.Context, id string, passframes []*IdentityValueInput) error {
+	resp, err := replaceIdentityValues(ctx, a.client, id, passframes)
+	if err != nil {
+		return err
+	}
+	if resp.AddIdentityValue == nil || len(resp.AddIdentityValue.Affected) != len(passframes) {
+		return fmt.Errorf("expected %d identity values to be written", len(passframes))
+	}
+	return nil
+}
+
+// verifyRotatedValue checks that value id holds exactly the staged passframes and that the own one decrypts to value
+func (a *ProtectedApi) verifyRotatedValue(ctx context.Context, id, ownerId, value string, staged []*IdentityValueInput) error {
+	written, err := a.GetValueByIdContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(written.Value) != len(staged) {
+		return fmt.Errorf("expected %d identity values, found %d", len(staged), len(written.Value))
+	}
+	for _, s := range staged {
+		found := helper.Filter(written.Value, func(v *getValueGetValueValueIdentityValue) bool {
+			return v.IdentityID == s.IdentityID
+		})
+		if len(found) != 1 || found[0].Passframe != s.Passframe {
+			return fmt.Errorf("identity value of identity %s was not written", s.IdentityID)
+		}
+	}
+	values := make([]EncryptenValue, 0, len(written.Value))
+	for _, v := range written.Value {
+		values = append(values, v)
+	}
+	decrypted, err := a.getDecryptedPassframe(ownerId, values)
+	if err != nil {
+		return err
+	}
+	if decrypted != value {
+		return fmt.Errorf("decrypted value does not match the rotated value")
+	}
+	return nil
+}
+
 // SyncValues sync all values by check identity and get all related identities which also has access to this value.
 func (a *ProtectedApi) SyncValues(identityId string) error {
-	values, err := a.GetAllRelatedValues(identityId)
+	return a.SyncValuesContext(context.Background(), identityId)
+}
+
+func (a *ProtectedApi) SyncValuesContext(ctx context.Context, identityId string) error {
+	values, err := a.GetAllRelatedValuesContext(ctx, identityId)
 	if err != nil {
 		return err
 	}
-	identity, err := a.GetIdentity(identityId)
+	identity, err := a.GetIdentityContext(ctx, identityId)
 	if err != nil {
 		return err
 	}
-	ownerPubKey, err := helper.NewBase64PublicPem(&a.authKey.PublicKey)
+	ownerPubKey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
 	if err != nil {
 		return err
 	}
@@ -262,8 +684,11 @@ func (a *ProtectedApi) SyncValues(identityId string) error {
 	}
 
 	for _, v := range values {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		values := make([]EncryptenValue, 0)
-		value, err := a.GetValueById(v.Id)
+		value, err := a.GetValueByIdContext(ctx, v.Id)
 		if err != nil {
 			return err
 		}
@@ -278,12 +703,16 @@ func (a *ProtectedApi) SyncValues(identityId string) error {
 			return gvgvv.IdentityID == identity.Id
 		})
 		if !hasValueForIdentityFound {
-			encyptedPassframe, err := identity.PublicKey.Encrypt(decryptedPassframe)
+			publicKey, err := a.trustedPublicKey(ctx, value.Name, identity.Id)
+			if err != nil {
+				return err
+			}
+			encyptedPassframe, err := publicKey.Encrypt(decryptedPassframe)
 
 			if err != nil {
 				return err
 			}
-			_, err = a.AddIdentityValue(IdentityValueInput{
+			_, err = a.AddIdentityValueContext(ctx, IdentityValueInput{
 				ValueID:    v.Id,
 				IdentityID: identity.Id,
 				Passframe:  encyptedPassframe,
@@ -297,15 +726,19 @@ func (a *ProtectedApi) SyncValues(identityId string) error {
 }
 
 func (a *ProtectedApi) SyncValue(id string) error {
-	value, err := a.GetValueById(id)
+	return a.SyncValueContext(context.Background(), id)
+}
+
+func (a *ProtectedApi) SyncValueContext(ctx context.Context, id string) error {
+	value, err := a.GetValueByIdContext(ctx, id)
 	if err != nil {
 		return err
 	}
-	resp, err := getRelatedIdenties(context.Background(), a.client, value.Name)
+	resp, err := getRelatedIdenties(ctx, a.client, value.Name)
 	if err != nil {
 		return err
 	}
-	ownerPubKey, err := helper.NewBase64PublicPem(&a.authKey.PublicKey)
+	ownerPubKey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
 	if err != nil {
 		return err
 	}
@@ -323,7 +756,7 @@ func (a *ProtectedApi) SyncValue(id string) error {
 		}
 	}
 	if !hasOwnId {
-		return errors.New("sender Identity has not the rights to update value")
+		return &PermissionDeniedError{IdentityId: ownerId, Target: RightTargetValues, Direction: DirectionsWrite, Resource: value.Name}
 	}
 	if err := a.checkIdentitiesHaveRelatedSignatureChain(resp.GetIdentitiesWithValueAccess()); err != nil {
 		return err
@@ -338,6 +771,9 @@ func (a *ProtectedApi) SyncValue(id string) error {
 		return err
 	}
 	for _, identity := range resp.IdentitiesWithValueAccess {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		hasValueForIdentityFound := helper.Includes(value.Value, func(gvgvv *getValueGetValueValueIdentityValue) bool {
 			return gvgvv.IdentityID == identity.Id
 		})
@@ -347,7 +783,7 @@ func (a *ProtectedApi) SyncValue(id string) error {
 			if err != nil {
 				return err
 			}
-			_, err = a.AddIdentityValue(IdentityValueInput{
+			_, err = a.AddIdentityValueContext(ctx, IdentityValueInput{
 				ValueID:    id,
 				IdentityID: identity.Id,
 				Passframe:  encyptedPassframe,
@@ -359,11 +795,14 @@ func (a *ProtectedApi) SyncValue(id string) error {
 	}
 
 	for _, v := range value.Value {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		res := helper.Filter[*getRelatedIdentiesIdentitiesWithValueAccessIdentity](resp.IdentitiesWithValueAccess, func(griiwvai *getRelatedIdentiesIdentitiesWithValueAccessIdentity) bool {
 			return v.IdentityID == griiwvai.Id
 		})
 		if len(res) == 0 {
-			_, err := deleteIdentityValue(context.Background(), a.client, v.Id)
+			_, err := deleteIdentityValue(ctx, a.client, v.Id)
 			if err != nil {
 				return err
 			}
@@ -373,8 +812,54 @@ func (a *ProtectedApi) SyncValue(id string) error {
 	return nil
 }
 
+// autoShareValues shares all values identityId can read and deletes the passframes of values it can no longer read.
+// It does nothing if the api was not created with WithAutoShareValues.
+func (a *ProtectedApi) autoShareValues(ctx context.Context, identityId string) error {
+	if !a.autoShare {
+		return nil
+	}
+	related, err := a.GetAllRelatedValuesContext(ctx, identityId)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrValueSharing, err)
+	}
+	relatedIds := make(map[string]bool)
+	var shareErr error
+	for _, v := range related {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("%w: %w", ErrValueSharing, err)
+		}
+		relatedIds[v.Id] = true
+		if err := a.SyncValueContext(ctx, v.Id); err != nil {
+			shareErr = errors.Join(shareErr, fmt.Errorf("value %s: %w", v.Name, err))
+		}
+	}
+
+	held, err := identityValuesOfIdentity(ctx, a.client, identityId)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrValueSharing, errors.Join(shareErr, err))
+	}
+	if held.QueryIdentityValue != nil {
+		for _, v := range held.QueryIdentityValue.Data {
+			if relatedIds[v.ValueID] {
+				continue
+			}
+			if _, err := deleteIdentityValue(ctx, a.client, v.Id); err != nil {
+				shareErr = errors.Join(shareErr, fmt.Errorf("value %s: %w", v.ValueID, err))
+			}
+		}
+	}
+	if shareErr != nil {
+		return fmt.Errorf("%w: %w", ErrValueSharing, shareErr)
+	}
+	return nil
+}
+
 func (a *ProtectedApi) AddIdentityValue(input IdentityValueInput) (string, error) {
-	resp, err := addIdentityValue(context.Background(), a.client, []*IdentityValueInput{{
+	return a.AddIdentityValueContext(context.Background(), input)
+}
+
+func (a *ProtectedApi) AddIdentityValueContext(ctx context.Context, input IdentityValueInput) (string, error) {
+	resp, err := addIdentityValue(ctx, a.client, []*IdentityValueInput{{
 		ValueID:    input.ValueID,
 		IdentityID: input.IdentityID,
 		Passframe:  input.Passframe,
@@ -382,6 +867,9 @@ func (a *ProtectedApi) AddIdentityValue(input IdentityValueInput) (string, error
 	if err != nil {
 		return "", err
 	}
+	if resp.AddIdentityValue == nil || len(resp.AddIdentityValue.Affected) == 0 {
+		return "", ErrNothingCreated
+	}
 	return resp.AddIdentityValue.Affected[0].Id, err
 }
 
@@ -392,7 +880,7 @@ type EncryptenValue interface {
 
 func (a *ProtectedApi) GetDecryptedPassframe(value []EncryptenValue) (string, error) {
 
-	pemKey, err := helper.NewBase64PublicPem(&a.authKey.PublicKey)
+	pemKey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
 	if err != nil {
 		return "", err
 	}
@@ -406,15 +894,19 @@ func (a *ProtectedApi) GetDecryptedPassframe(value []EncryptenValue) (string, er
 func (a *ProtectedApi) getDecryptedPassframe(identityId string, value []EncryptenValue) (string, error) {
 	for _, v := range value {
 		if v.GetIdentityID() == identityId {
-			key, err := helper.Decrypt(a.authKey, v.GetPassframe())
+			key, err := a.signer.Decrypt(v.GetPassframe())
 			return string(key), err
 		}
 	}
-	return "", errors.New("getEncryptedPassframe: given Identity not found at saved values ")
+	return "", &IdentityValueMissingError{IdentityId: identityId}
 }
 
 func (a *ProtectedApi) GetAllRelatedValues(identityId string) ([]*allRelatedValuesAllRelatedValuesValue, error) {
-	resp, err := allRelatedValues(context.Background(), a.client, identityId)
+	return a.GetAllRelatedValuesContext(context.Background(), identityId)
+}
+
+func (a *ProtectedApi) GetAllRelatedValuesContext(ctx context.Context, identityId string) ([]*allRelatedValuesAllRelatedValuesValue, error) {
+	resp, err := allRelatedValues(ctx, a.client, identityId)
 	if err != nil {
 		return nil, err
 	}
@@ -422,7 +914,11 @@ func (a *ProtectedApi) GetAllRelatedValues(identityId string) ([]*allRelatedValu
 }
 
 func (a *ProtectedApi) GetAllRelatedValuesWithIdentityValues(identityId string) ([]*allRelatedValuesWithIdentityValuesAllRelatedValuesValue, error) {
-	resp, err := allRelatedValuesWithIdentityValues(context.Background(), a.client, identityId)
+	return a.GetAllRelatedValuesWithIdentityValuesContext(context.Background(), identityId)
+}
+
+func (a *ProtectedApi) GetAllRelatedValuesWithIdentityValuesContext(ctx context.Context, identityId string) ([]*allRelatedValuesWithIdentityValuesAllRelatedValuesValue, error) {
+	resp, err := allRelatedValuesWithIdentityValues(ctx, a.client, identityId)
 	if err != nil {
 		return nil, err
 	}
@@ -430,46 +926,98 @@ func (a *ProtectedApi) GetAllRelatedValuesWithIdentityValues(identityId string)
 }
 
 func (a *ProtectedApi) GetAllRelatedValuesWithIdentityValuesAndPassframe(identityId string) ([]*allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue, error) {
-	resp, err := allRelatedValuesWithIdentityValuesAndSecret(context.Background(), a.client, identityId)
+	return a.GetAllRelatedValuesWithIdentityValuesAndPassframeContext(context.Background(), identityId)
+}
+
+func (a *ProtectedApi) GetAllRelatedValuesWithIdentityValuesAndPassframeContext(ctx context.Context, identityId string) ([]*allRelatedValuesWithIdentityValuesAndSecretAllRelatedValuesValue, error) {
+	resp, err := allRelatedValuesWithIdentityValuesAndSecret(ctx, a.client, identityId)
 	if err != nil {
 		return nil, err
 	}
 	return resp.AllRelatedValues, nil
 }
 
-func (a *ProtectedApi) checkIdentityHaveRelatedSignatureChain(identity *getRelatedIdentiesIdentitiesWithValueAccessIdentity, other []*getRelatedIdentiesIdentitiesWithValueAccessIdentity) error {
-
-	if identity.IsOperator {
-		return nil
-	}
-
-	creator, _, err := helper.DecodeCreatorJWT(identity.GetCreatorVerification())
+// trustedPublicKey returns the public key of identityId after its creator chain was verified among
+// all identities with access to the value name
+func (a *ProtectedApi) trustedPublicKey(ctx context.Context, name, identityId string) (helper.Base64PublicPem, error) {
+	resp, err := getRelatedIdenties(ctx, a.client, name)
 	if err != nil {
-		return err
+		return "", err
 	}
-
-	creatorIdentity := helper.Filter(other, func(griiwvai *getRelatedIdentiesIdentitiesWithValueAccessIdentity) bool {
-		return griiwvai.Id == creator.CreatorTokenId
-	})
-	if len(creatorIdentity) != 1 {
-		return fmt.Errorf("Something strange creator identity was not found, or more than one was found. this could not be possible, cause of permission chain")
+	byId := make(map[string]*getRelatedIdentiesIdentitiesWithValueAccessIdentity, len(resp.IdentitiesWithValueAccess))
+	for _, v := range resp.IdentitiesWithValueAccess {
+		byId[v.Id] = v
 	}
-	creatorPubKey, err := creatorIdentity[0].GetPublicKey().GetPublicKey()
-	if err != nil {
-		return err
+	identity, ok := byId[identityId]
+	if !ok {
+		return "", &UntrustedIdentitiesError{Identities: []UntrustedIdentity{{IdentityId: identityId, Reason: fmt.Sprintf("not returned as identity with access to %s", name)}}}
 	}
-	_, err = helper.VerifyCreatorJWT(creatorPubKey, identity.CreatorVerification)
-	if err != nil {
-		return err
+	if reason := a.checkIdentityHaveRelatedSignatureChain(identity, byId, make(map[string]bool)); reason != "" {
+		return "", &UntrustedIdentitiesError{Identities: []UntrustedIdentity{{IdentityId: identityId, Reason: reason}}}
+	}
+	return identity.PublicKey, nil
+}
+
+// checkIdentityHaveRelatedSignatureChain follows the creators of identity within other up to an operator.
+// It returns the reason why the identity is not trusted or an empty string. Trusted identities are added to verified.
+func (a *ProtectedApi) checkIdentityHaveRelatedSignatureChain(identity *getRelatedIdentiesIdentitiesWithValueAccessIdentity, other map[string]*getRelatedIdentiesIdentitiesWithValueAccessIdentity, verified map[string]bool) string {
+	chain := make([]string, 0)
+	seen := make(map[string]bool)
+	current := identity
+	for !verified[current.Id] {
+		if seen[current.Id] {
+			return fmt.Sprintf("creator chain leads back to identity %s", current.Id)
+		}
+		seen[current.Id] = true
+		if _, err := identityPublicKey(current.PublicKey, current.Id, a.vaultId); err != nil {
+			return fmt.Sprintf("identity %s: %v", current.Id, err)
+		}
+		chain = append(chain, current.Id)
+		if a.isTrustAnchor(current.Id, current.IsOperator) {
+			break
+		}
+		if current.IsOperator {
+			return fmt.Sprintf("operator %s is no pinned trust anchor", current.Id)
+		}
+		creator, _, err := helper.DecodeCreatorJWT(current.CreatorVerification)
+		if err != nil {
+			return fmt.Sprintf("identity %s: %v", current.Id, err)
+		}
+		creatorIdentity, ok := other[creator.CreatorTokenId]
+		if !ok {
+			return fmt.Sprintf("creator %s of identity %s not found", creator.CreatorTokenId, current.Id)
+		}
+		creatorKey, err := creatorIdentity.PublicKey.GetPublicKey()
+		if err != nil {
+			return fmt.Sprintf("creator %s: %v", creatorIdentity.Id, err)
+		}
+		if _, err := verifyCreatorVerification(creatorKey, current.Id, a.vaultId, current.CreatorVerification); err != nil {
+			return fmt.Sprintf("identity %s: %v", current.Id, err)
+		}
+		current = creatorIdentity
+	}
+	for _, id := range chain {
+		verified[id] = true
 	}
-	return a.checkIdentityHaveRelatedSignatureChain(creatorIdentity[0], other)
+	return ""
 }
 
+// checkIdentitiesHaveRelatedSignatureChain verifies that every identity is signed by a chain of creators
+// within identies which ends at an operator. Otherwise an *UntrustedIdentitiesError is returned.
 func (a *ProtectedApi) checkIdentitiesHaveRelatedSignatureChain(identies []*getRelatedIdentiesIdentitiesWithValueAccessIdentity) error {
+	byId := make(map[string]*getRelatedIdentiesIdentitiesWithValueAccessIdentity, len(identies))
 	for _, identity := range identies {
-		if err := a.checkIdentityHaveRelatedSignatureChain(identity, identies); err != nil {
-			return err
+		byId[identity.Id] = identity
+	}
+	verified := make(map[string]bool, len(identies))
+	untrusted := make([]UntrustedIdentity, 0)
+	for _, identity := range identies {
+		if reason := a.checkIdentityHaveRelatedSignatureChain(identity, byId, verified); reason != "" {
+			untrusted = append(untrusted, UntrustedIdentity{IdentityId: identity.Id, Reason: reason})
 		}
 	}
+	if len(untrusted) > 0 {
+		return &UntrustedIdentitiesError{Identities: untrusted}
+	}
 	return nil
 }
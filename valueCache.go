@@ -0,0 +1,148 @@
+package api
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// valueCache keeps decrypted values of one identity in memory, see WithValueCache.
+// Entries are found by value id and by name and are evicted least recently used first.
+type valueCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+	entries    *list.List
+	byId       map[string]*list.Element
+	byName     map[string]*list.Element
+}
+
+type valueCacheEntry struct {
+	value     IdentityValue
+	passframe string
+	plaintext []byte
+	expires   time.Time
+}
+
+func newValueCache(ttl time.Duration, maxEntries int) *valueCache {
+	return &valueCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    list.New(),
+		byId:       make(map[string]*list.Element),
+		byName:     make(map[string]*list.Element),
+	}
+}
+
+// get returns the cached value with id or name, if it did not expire yet
+func (c *valueCache) get(id, name string) (*IdentityValue, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem := c.lookup(id, name)
+	if elem == nil {
+		return nil, false
+	}
+	entry := elem.Value.(*valueCacheEntry)
+	if !c.now().Before(entry.expires) {
+		return nil, false
+	}
+	c.entries.MoveToFront(elem)
+	return entry.identityValue(), true
+}
+
+// reuse returns the plaintext of an expired entry if passframe and update time of the value did not change,
+// so the value does not need to be decrypted again
+func (c *valueCache) reuse(value *IdentityValue, passframe string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem := c.lookup(value.Id, "")
+	if elem == nil {
+		return nil, false
+	}
+	entry := elem.Value.(*valueCacheEntry)
+	if entry.passframe != passframe || !equalTime(entry.value.UpdatedAt, value.UpdatedAt) {
+		return nil, false
+	}
+	return append([]byte(nil), entry.plaintext...), true
+}
+
+// put stores plaintext as decrypted secret of value, the cache takes ownership of plaintext
+func (c *valueCache) put(value *IdentityValue, passframe string, plaintext []byte) *IdentityValue {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// id and name may point to different entries, f.e.: if a value was deleted and added again with the same name
+	if elem, ok := c.byId[value.Id]; ok {
+		c.remove(elem)
+	}
+	if elem, ok := c.byName[value.Name]; ok {
+		c.remove(elem)
+	}
+	entry := &valueCacheEntry{
+		value:     *value,
+		passframe: passframe,
+		plaintext: plaintext,
+		expires:   c.now().Add(c.ttl),
+	}
+	entry.value.Value = ""
+	elem := c.entries.PushFront(entry)
+	c.byId[value.Id] = elem
+	c.byName[value.Name] = elem
+	for c.maxEntries > 0 && c.entries.Len() > c.maxEntries {
+		c.remove(c.entries.Back())
+	}
+	return entry.identityValue()
+}
+
+// invalidate removes the value with id or name idOrName
+func (c *valueCache) invalidate(idOrName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem := c.lookup(idOrName, idOrName); elem != nil {
+		c.remove(elem)
+	}
+}
+
+func (c *valueCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.entries.Len() > 0 {
+		c.remove(c.entries.Back())
+	}
+}
+
+func (c *valueCache) lookup(id, name string) *list.Element {
+	if elem, ok := c.byId[id]; ok && id != "" {
+		return elem
+	}
+	if elem, ok := c.byName[name]; ok && name != "" {
+		return elem
+	}
+	return nil
+}
+
+// remove deletes elem and overwrites its plaintext. Copies handed out as string are not affected.
+func (c *valueCache) remove(elem *list.Element) {
+	entry := c.entries.Remove(elem).(*valueCacheEntry)
+	if c.byId[entry.value.Id] == elem {
+		delete(c.byId, entry.value.Id)
+	}
+	if c.byName[entry.value.Name] == elem {
+		delete(c.byName, entry.value.Name)
+	}
+	clear(entry.plaintext)
+}
+
+func (e *valueCacheEntry) identityValue() *IdentityValue {
+	value := e.value
+	value.Value = string(e.plaintext)
+	return &value
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/cryptvault-cloud/api/vaulttest"
+)
+
+func TestValueCache(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := newValueCache(time.Minute, 2)
+	c.now = func() time.Time { return now }
+
+	a := []byte("secret-a")
+	updatedAt := now
+	c.put(&IdentityValue{Id: "a", Name: "VALUES.a", UpdatedAt: &updatedAt}, "frame-a", a)
+	if v, ok := c.get("", "VALUES.a"); !ok || v.Value != "secret-a" {
+		t.Fatalf("get() by name = %v, %v, want secret-a", v, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.get("a", ""); ok {
+		t.Error("get() returned an expired value")
+	}
+	if plaintext, ok := c.reuse(&IdentityValue{Id: "a", UpdatedAt: &now}, "frame-a"); ok {
+		t.Errorf("reuse() after update = %s, want no reuse", plaintext)
+	}
+	if plaintext, ok := c.reuse(&IdentityValue{Id: "a", UpdatedAt: &updatedAt}, "frame-a"); !ok || string(plaintext) != "secret-a" {
+		t.Errorf("reuse() of unchanged value = %s, %v, want secret-a", plaintext, ok)
+	}
+
+	c.put(&IdentityValue{Id: "b", Name: "VALUES.b"}, "frame-b", []byte("secret-b"))
+	c.put(&IdentityValue{Id: "c", Name: "VALUES.c"}, "frame-c", []byte("secret-c"))
+	if _, ok := c.get("a", ""); ok {
+		t.Error("get() returned the least recently used value beyond maxEntries")
+	}
+	if !bytes.Equal(a, make([]byte, len(a))) {
+		t.Errorf("plaintext of evicted value = %q, want zeroed", a)
+	}
+
+	c.invalidate("VALUES.b")
+	if _, ok := c.get("b", ""); ok {
+		t.Error("get() returned an invalidated value")
+	}
+	c.clear()
+	if _, ok := c.get("c", ""); ok || c.entries.Len() != 0 {
+		t.Error("get() returned a value after clear")
+	}
+}
+
+func TestValueCachePutReplacesIdAndName(t *testing.T) {
+	c := newValueCache(time.Minute, 10)
+	old := []byte("secret-old")
+	c.put(&IdentityValue{Id: "a", Name: "VALUES.x"}, "frame-a", old)
+	c.put(&IdentityValue{Id: "b", Name: "VALUES.y"}, "frame-b", []byte("secret-b"))
+
+	// a was deleted and b renamed to its name
+	c.put(&IdentityValue{Id: "b", Name: "VALUES.x"}, "frame-b", []byte("secret-new"))
+	if v, ok := c.get("a", ""); ok {
+		t.Errorf("get() of replaced id = %s, want no value", v.Value)
+	}
+	if v, ok := c.get("", "VALUES.x"); !ok || v.Id != "b" {
+		t.Errorf("get() by name = %v, %v, want b", v, ok)
+	}
+	if c.entries.Len() != 1 || !bytes.Equal(old, make([]byte, len(old))) {
+		t.Errorf("cache holds %d entries, plaintext of replaced value = %q, want 1 entry and zeroed", c.entries.Len(), old)
+	}
+}
+
+func TestValueCacheSkipsRequestAndDecryption(t *testing.T) {
+	server := vaulttest.NewServer()
+	defer server.Close()
+
+	a := NewApi(server.URL, http.DefaultClient, WithValueCache(time.Minute, 10))
+	private, _, vaultId, err := a.NewVault("test", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := &CountingSigner{PrivateKeySigner: NewPrivateKeySigner(private)}
+	protected := a.GetProtectedApiWithSigner(signer, vaultId)
+
+	valueId, err := protected.AddValue("VALUES.cached", "secret", ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		value, err := protected.GetIdentityValueByName("VALUES.cached")
+		if err != nil {
+			t.Fatalf("GetIdentityValueByName() error = %v", err)
+		}
+		if value.Value != "secret" {
+			t.Errorf("GetIdentityValueByName() = %s, want secret", value.Value)
+		}
+	}
+	if signer.Decrypted != 1 {
+		t.Errorf("decrypted %d times, want 1", signer.Decrypted)
+	}
+
+	if _, err := protected.UpdateValue(valueId, "VALUES.cached", "updated", ValueTypeString); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+	value, err := protected.GetIdentityValueById(valueId)
+	if err != nil {
+		t.Fatalf("GetIdentityValueById() error = %v", err)
+	}
+	if value.Value != "updated" {
+		t.Errorf("GetIdentityValueById() after update = %s, want updated", value.Value)
+	}
+
+	if err := protected.DeleteValue(valueId); err != nil {
+		t.Fatalf("DeleteValue() error = %v", err)
+	}
+	if _, err := protected.GetIdentityValueById(valueId); err == nil {
+		t.Error("GetIdentityValueById() after delete returned the cached value")
+	}
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultValuePageSize is the page size of a ValueIterator if none is given
+const DefaultValuePageSize = 100
+
+// Page selects a part of a listing. First is the maximum number of entries, 0 means no limit.
+type Page struct {
+	First  int
+	Offset int
+}
+
+// ValueInfo is the metadata of a value, the secret is not decrypted
+type ValueInfo struct {
+	Id        string
+	Name      string
+	Type      ValueType
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	// Readers is the number of identities the value is shared with
+	Readers int
+}
+
+// ValueList is a page of values returned by ListValues
+type ValueList struct {
+	Values []*ValueInfo
+	// TotalCount is the number of values matching the filter regardless of the page
+	TotalCount int
+}
+
+func (a *ProtectedApi) ListValues(filter *ValueFiltersInput, order *ValueOrder, page Page) (*ValueList, error) {
+	return a.ListValuesContext(context.Background(), filter, order, page)
+}
+
+// ListValuesContext lists the values visible to the identity which match filter, ordered by order.
+// filter and order may be nil. Prior versions kept by WithValueHistory and chunks of binary values are never listed.
+func (a *ProtectedApi) ListValuesContext(ctx context.Context, filter *ValueFiltersInput, order *ValueOrder, page Page) (*ValueList, error) {
+	versionSegment, chunkSegment := valueVersionSegment, valueChunkSegment
+	and := []*ValueFiltersInput{
+		{Name: &StringFilterInput{NotContains: &versionSegment}},
+		{Name: &StringFilterInput{NotContains: &chunkSegment}},
+	}
+	if filter != nil {
+		and = append(and, filter)
+	}
+	var first, offset *int
+	if page.First > 0 {
+		first = &page.First
+	}
+	if page.Offset > 0 {
+		offset = &page.Offset
+	}
+	resp, err := listValues(ctx, a.client, &ValueFiltersInput{And: and}, order, first, offset)
+	if err != nil {
+		return nil, err
+	}
+	list := &ValueList{Values: make([]*ValueInfo, 0)}
+	if resp.QueryValue == nil {
+		return list, nil
+	}
+	list.TotalCount = resp.QueryValue.TotalCount
+	for _, v := range resp.QueryValue.Data {
+		list.Values = append(list.Values, &ValueInfo{
+			Id:        v.Id,
+			Name:      v.Name,
+			Type:      v.Type,
+			CreatedAt: v.CreatedAt,
+			UpdatedAt: v.UpdatedAt,
+			Readers:   len(v.Value),
+		})
+	}
+	return list, nil
+}
+
+// ValueIterator pages through all values matching a filter, see IterateValues.
+//
+//	it := api.IterateValues(filter, nil, 0)
+//	for it.Next(ctx) {
+//		fmt.Println(it.Value().Name)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ValueIterator struct {
+	api      *ProtectedApi
+	filter   *ValueFiltersInput
+	order    *ValueOrder
+	pageSize int
+	offset   int
+	page     []*ValueInfo
+	current  *ValueInfo
+	total    int
+	done     bool
+	err      error
+}
+
+// IterateValues returns an iterator over all values matching filter, loading pageSize values per request.
+// Without order values are ordered by id, so pages are stable. Values added or deleted while iterating
+// may shift the pages, so a value can be skipped or returned twice.
+func (a *ProtectedApi) IterateValues(filter *ValueFiltersInput, order *ValueOrder, pageSize int) *ValueIterator {
+	if pageSize <= 0 {
+		pageSize = DefaultValuePageSize
+	}
+	if order == nil {
+		byId := ValueOrderableId
+		order = &ValueOrder{Asc: &byId}
+	}
+	return &ValueIterator{api: a, filter: filter, order: order, pageSize: pageSize}
+}
+
+// Next advances to the next value and loads the next page if needed.
+// It returns false once all values were returned or an error occurred, see Err.
+func (it *ValueIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.page) == 0 && !it.done {
+		list, err := it.api.ListValuesContext(ctx, it.filter, it.order, Page{First: it.pageSize, Offset: it.offset})
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = list.Values
+		it.total = list.TotalCount
+		it.offset += len(list.Values)
+		it.done = len(list.Values) < it.pageSize || it.offset >= list.TotalCount
+	}
+	if len(it.page) == 0 {
+		it.current = nil
+		return false
+	}
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Value returns the current value, nil before the first call of Next
+func (it *ValueIterator) Value() *ValueInfo {
+	return it.current
+}
+
+// TotalCount returns the number of matching values reported with the last page
+func (it *ValueIterator) TotalCount() int {
+	return it.total
+}
+
+// Err returns the error which stopped the iteration
+func (it *ValueIterator) Err() error {
+	return it.err
+}
@@ -0,0 +1,53 @@
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cryptvault-cloud/api"
+)
+
+func TestListValues(t *testing.T) {
+	_, operator, _ := newTestVault(t, api.WithValueHistory(2))
+	ctx := context.Background()
+
+	for i := 1; i <= 5; i++ {
+		if _, err := operator.AddValue(fmt.Sprintf("VALUES.a.%d", i), "secret", api.ValueTypeString); err != nil {
+			t.Fatalf("AddValue() error = %v", err)
+		}
+	}
+	valueId, err := operator.AddValue("VALUES.b.x", `{"v": 1}`, api.ValueTypeJson)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	// keeps a prior version, which must not be listed
+	if _, err := operator.UpdateValue(valueId, "VALUES.b.x", `{"v": 2}`, api.ValueTypeJson); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+
+	prefix := "VALUES.a."
+	byName := api.ValueOrderableName
+	list, err := operator.ListValuesContext(ctx, &api.ValueFiltersInput{Name: &api.StringFilterInput{StartsWith: &prefix}}, &api.ValueOrder{Desc: &byName}, api.Page{First: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("ListValues() error = %v", err)
+	}
+	if list.TotalCount != 5 || len(list.Values) != 2 || list.Values[0].Name != "VALUES.a.4" || list.Values[1].Name != "VALUES.a.3" {
+		t.Errorf("ListValues() = %d values %v, want VALUES.a.4 and VALUES.a.3 of 5", list.TotalCount, list.Values)
+	}
+	if v := list.Values[0]; v.Type != api.ValueTypeString || v.CreatedAt == nil || v.Readers != 1 {
+		t.Errorf("ListValues() value = %+v, want type, timestamps and 1 reader", v)
+	}
+
+	it := operator.IterateValues(nil, nil, 2)
+	names := make([]string, 0)
+	for it.Next(ctx) {
+		names = append(names, it.Value().Name)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("IterateValues() error = %v", err)
+	}
+	if len(names) != 6 || it.TotalCount() != 6 {
+		t.Errorf("IterateValues() = %v of %d, want 6 values without versions", names, it.TotalCount())
+	}
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// valueVersionSegment separates the name of a value from the number of its version
+const valueVersionSegment = "._version."
+
+// ValueVersion is a prior secret of a value kept by WithValueHistory
+type ValueVersion struct {
+	Version int       `json:"version"`
+	Id      string    `json:"id"`
+	Type    ValueType `json:"type"`
+	// CreatedAt is the time the version was replaced
+	CreatedAt *time.Time `json:"createdAt"`
+}
+
+func valueVersionName(name string, version int) string {
+	return name + valueVersionSegment + strconv.Itoa(version)
+}
+
+func (a *ProtectedApi) ListValueVersions(id string) ([]*ValueVersion, error) {
+	return a.ListValueVersionsContext(context.Background(), id)
+}
+
+// ListValueVersionsContext returns all kept versions of value id, oldest first
+func (a *ProtectedApi) ListValueVersionsContext(ctx context.Context, id string) ([]*ValueVersion, error) {
+	value, err := a.GetValueByIdContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return a.listValueVersions(ctx, value.Name)
+}
+
+func (a *ProtectedApi) listValueVersions(ctx context.Context, name string) ([]*ValueVersion, error) {
+	prefix := name + valueVersionSegment
+	resp, err := valueVersions(ctx, a.client, prefix)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]*ValueVersion, 0)
+	if resp.QueryValue == nil {
+		return versions, nil
+	}
+	for _, v := range resp.QueryValue.Data {
+		version, err := strconv.Atoi(strings.TrimPrefix(v.Name, prefix))
+		if err != nil || version < 1 {
+			// some other value stored below the name
+			continue
+		}
+		versions = append(versions, &ValueVersion{Version: version, Id: v.Id, Type: v.Type, CreatedAt: v.CreatedAt})
+	}
+	slices.SortFunc(versions, func(a, b *ValueVersion) int {
+		return a.Version - b.Version
+	})
+	return versions, nil
+}
+
+func (a *ProtectedApi) GetIdentityValueVersion(id string, version int) (*IdentityValue, error) {
+	return a.GetIdentityValueVersionContext(context.Background(), id, version)
+}
+
+// GetIdentityValueVersionContext returns the decrypted version of value id
+func (a *ProtectedApi) GetIdentityValueVersionContext(ctx context.Context, id string, version int) (*IdentityValue, error) {
+	versions, err := a.ListValueVersionsContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if v.Version == version {
+			return a.GetIdentityValueByIdContext(ctx, v.Id)
+		}
+	}
+	return nil, ErrValueVersionNotFound
+}
+
+func (a *ProtectedApi) RestoreValueVersion(id string, version int) (string, error) {
+	return a.RestoreValueVersionContext(context.Background(), id, version)
+}
+
+// RestoreValueVersionContext updates value id to the secret of version. The replaced secret is kept as new version.
+func (a *ProtectedApi) RestoreValueVersionContext(ctx context.Context, id string, version int) (string, error) {
+	value, err := a.GetValueByIdContext(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	old, err := a.GetIdentityValueVersionContext(ctx, id, version)
+	if err != nil {
+		return "", err
+	}
+	return a.UpdateValueContext(ctx, id, value.Name, old.Value, old.Type)
+}
+
+// snapshotValue stores the current passframes of value as its next version and returns the id of the version.
+// The passframes are copied as they are, identities without read right for the version are skipped.
+// It does nothing if the api was not created with WithValueHistory.
+func (a *ProtectedApi) snapshotValue(ctx context.Context, value *getValueGetValue) (string, error) {
+	if a.valueHistory <= 0 {
+		return "", nil
+	}
+	versions, err := a.listValueVersions(ctx, value.Name)
+	if err != nil {
+		return "", err
+	}
+	next := 1
+	if len(versions) > 0 {
+		next = versions[len(versions)-1].Version + 1
+	}
+	name := valueVersionName(value.Name, next)
+	related, err := getRelatedIdenties(ctx, a.client, name)
+	if err != nil {
+		return "", err
+	}
+	canRead := make(map[string]bool)
+	for _, v := range related.IdentitiesWithValueAccess {
+		canRead[v.Id] = true
+	}
+
+	added, err := addValue(ctx, a.client, name, value.Type)
+	if err != nil {
+		return "", err
+	}
+	if added.AddValue == nil || len(added.AddValue.Affected) == 0 {
+		return "", ErrNothingCreated
+	}
+	versionId := added.AddValue.Affected[0].Id
+	identityValues := make([]*IdentityValueInput, 0, len(value.Value))
+	for _, v := range value.Value {
+		if canRead[v.IdentityID] {
+			identityValues = append(identityValues, &IdentityValueInput{ValueID: versionId, IdentityID: v.IdentityID, Passframe: v.Passframe})
+		}
+	}
+	if _, err := addIdentityValue(ctx, a.client, identityValues); err != nil {
+		return "", errors.Join(err, a.DeleteValueContext(context.WithoutCancel(ctx), versionId))
+	}
+	return versionId, nil
+}
+
+// rollbackValueVersion deletes the version versionId taken by snapshotValue after the change failed with err
+func (a *ProtectedApi) rollbackValueVersion(ctx context.Context, versionId string, err error) error {
+	if versionId == "" {
+		return err
+	}
+	// the rollback must not be skipped only because the caller context was cancelled
+	if _, deleteErr := deleteValue(context.WithoutCancel(ctx), a.client, versionId); deleteErr != nil {
+		return errors.Join(err, fmt.Errorf("rollback: %w", deleteErr))
+	}
+	return err
+}
+
+// pruneValueVersions deletes the oldest versions of the value name until only the configured number is left
+func (a *ProtectedApi) pruneValueVersions(ctx context.Context, name string) error {
+	if a.valueHistory <= 0 {
+		return nil
+	}
+	versions, err := a.listValueVersions(ctx, name)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(versions)-a.valueHistory; i++ {
+		if _, err := deleteValue(ctx, a.client, versions[i].Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameValueVersions moves all versions of the value oldName below newName
+func (a *ProtectedApi) renameValueVersions(ctx context.Context, oldName, newName string) error {
+	versions, err := a.listValueVersions(ctx, oldName)
+	if err != nil {
+		return err
+	}
+	for _, v := range versions {
+		if _, err := updateValue(ctx, a.client, v.Id, valueVersionName(newName, v.Version), v.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,471 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cryptvault-cloud/api"
+	"github.com/cryptvault-cloud/api/vaulttest"
+	"github.com/cryptvault-cloud/helper"
+)
+
+func newTestVault(t *testing.T, opts ...api.Option) (a api.ApiHandler, operator api.ProtectedApiHandler, vaultId string) {
+	t.Helper()
+	server := vaulttest.NewServer()
+	t.Cleanup(server.Close)
+
+	a = api.NewApi(server.URL, http.DefaultClient, opts...)
+	private, _, vaultId, err := a.NewVault("test", "token")
+	if err != nil {
+		t.Fatalf("NewVault() error = %v", err)
+	}
+	return a, a.GetProtectedApi(private, vaultId), vaultId
+}
+
+func TestAddValueAndSyncValue(t *testing.T) {
+	a, operator, vaultId := newTestVault(t)
+
+	valueId, err := operator.AddValue("VALUES.a.b", "secret", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	value, err := operator.GetIdentityValueByName("VALUES.a.b")
+	if err != nil {
+		t.Fatalf("GetIdentityValueByName() error = %v", err)
+	}
+	if value.Id != valueId || value.Value != "secret" {
+		t.Errorf("GetIdentityValueByName() = %+v, want id %s and value secret", value, valueId)
+	}
+
+	rights, err := api.GetRightDescriptionByString("(r)VALUES.a.>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader, err := operator.CreateIdentity("reader", []*api.RightInput{{Target: rights[0].Target, Right: rights[0].Right, RightValuePattern: rights[0].RightValue}})
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	readerApi := a.GetProtectedApi(reader.PrivateKey, vaultId)
+
+	_, err = readerApi.GetIdentityValueById(valueId)
+	if !errors.Is(err, api.ErrIdentityValueMissing) {
+		t.Errorf("GetIdentityValueById() before sync error = %v, want %v", err, api.ErrIdentityValueMissing)
+	}
+
+	if err := operator.SyncValue(valueId); err != nil {
+		t.Fatalf("SyncValue() error = %v", err)
+	}
+	value, err = readerApi.GetIdentityValueById(valueId)
+	if err != nil {
+		t.Fatalf("GetIdentityValueById() error = %v", err)
+	}
+	if value.Value != "secret" {
+		t.Errorf("GetIdentityValueById() = %s, want secret", value.Value)
+	}
+
+	var gqlErr *api.GraphQLError
+	if _, err := readerApi.AddValue("VALUES.a.c", "other", api.ValueTypeString); !errors.As(err, &gqlErr) {
+		t.Errorf("AddValue() without write right error = %v, want *GraphQLError", err)
+	}
+
+	if _, err := operator.UpdateValue(valueId, "VALUES.a.b", "updated", api.ValueTypeString); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+	value, err = readerApi.GetIdentityValueByName("VALUES.a.b")
+	if err != nil {
+		t.Fatalf("GetIdentityValueByName() error = %v", err)
+	}
+	if value.Value != "updated" {
+		t.Errorf("GetIdentityValueByName() after update = %s, want updated", value.Value)
+	}
+
+	if err := operator.DeleteValue(valueId); err != nil {
+		t.Fatalf("DeleteValue() error = %v", err)
+	}
+	if _, err := operator.GetValueById(valueId); !errors.Is(err, api.ErrValueNotFound) {
+		t.Errorf("GetValueById() after delete error = %v, want %v", err, api.ErrValueNotFound)
+	}
+}
+
+func TestRotateValue(t *testing.T) {
+	a, operator, vaultId := newTestVault(t)
+
+	valueId, err := operator.AddValue("VALUES.a.b", "secret", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	reader, err := operator.CreateIdentity("reader", rightInputs(t, "(r)VALUES.a.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	if err := operator.SyncValue(valueId); err != nil {
+		t.Fatalf("SyncValue() error = %v", err)
+	}
+	readerApi := a.GetProtectedApi(reader.PrivateKey, vaultId)
+
+	if err := operator.RotateValueContext(context.Background(), valueId, "rotated"); err != nil {
+		t.Fatalf("RotateValue() error = %v", err)
+	}
+	value, err := readerApi.GetIdentityValueById(valueId)
+	if err != nil {
+		t.Fatalf("GetIdentityValueById() error = %v", err)
+	}
+	if value.Value != "rotated" {
+		t.Errorf("GetIdentityValueById() after rotation = %s, want rotated", value.Value)
+	}
+}
+
+func TestRotateValueRollsBack(t *testing.T) {
+	server := vaulttest.NewServer()
+	t.Cleanup(server.Close)
+	a := api.NewApi(server.URL, http.DefaultClient)
+	private, _, vaultId, err := a.NewVault("test", "token")
+	if err != nil {
+		t.Fatalf("NewVault() error = %v", err)
+	}
+	operator := a.GetProtectedApi(private, vaultId)
+	valueId, err := operator.AddValue("VALUES.a.b", "secret", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+
+	// the batch reaches the server but its response gets lost
+	lost := false
+	failing := a.GetProtectedApiWithHttpClient(private, vaultId, &http.Client{
+		Transport: api.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			resp, err := http.DefaultTransport.RoundTrip(req)
+			if err == nil && !lost && strings.Contains(string(body), "replaceIdentityValues") {
+				lost = true
+				resp.Body.Close()
+				return nil, errors.New("connection reset")
+			}
+			return resp, err
+		}),
+	})
+
+	err = failing.RotateValueContext(context.Background(), valueId, "rotated")
+	if !errors.Is(err, api.ErrValueRotation) {
+		t.Fatalf("RotateValue() error = %v, want %v", err, api.ErrValueRotation)
+	}
+	value, err := operator.GetIdentityValueById(valueId)
+	if err != nil {
+		t.Fatalf("GetIdentityValueById() error = %v", err)
+	}
+	if value.Value != "secret" {
+		t.Errorf("GetIdentityValueById() after rollback = %s, want secret", value.Value)
+	}
+}
+
+func TestValueVersions(t *testing.T) {
+	_, operator, _ := newTestVault(t, api.WithValueHistory(2))
+	ctx := context.Background()
+
+	valueId, err := operator.AddValue("VALUES.a.b", "secret", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	for _, v := range []string{"first", "second", "third"} {
+		if _, err := operator.UpdateValue(valueId, "VALUES.a.b", v, api.ValueTypeString); err != nil {
+			t.Fatalf("UpdateValue(%s) error = %v", v, err)
+		}
+	}
+
+	versions, err := operator.ListValueVersionsContext(ctx, valueId)
+	if err != nil {
+		t.Fatalf("ListValueVersions() error = %v", err)
+	}
+	if len(versions) != 2 || versions[0].Version != 2 || versions[1].Version != 3 {
+		t.Fatalf("ListValueVersions() = %+v, want versions 2 and 3", versions)
+	}
+	version, err := operator.GetIdentityValueVersionContext(ctx, valueId, 2)
+	if err != nil {
+		t.Fatalf("GetIdentityValueVersion() error = %v", err)
+	}
+	if version.Value != "first" {
+		t.Errorf("GetIdentityValueVersion() = %s, want first", version.Value)
+	}
+	if _, err := operator.GetIdentityValueVersionContext(ctx, valueId, 1); !errors.Is(err, api.ErrValueVersionNotFound) {
+		t.Errorf("GetIdentityValueVersion() of pruned version error = %v, want %v", err, api.ErrValueVersionNotFound)
+	}
+
+	if _, err := operator.RestoreValueVersionContext(ctx, valueId, 2); err != nil {
+		t.Fatalf("RestoreValueVersion() error = %v", err)
+	}
+	value, err := operator.GetIdentityValueById(valueId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.Value != "first" {
+		t.Errorf("GetIdentityValueById() after restore = %s, want first", value.Value)
+	}
+	version, err = operator.GetIdentityValueVersionContext(ctx, valueId, 4)
+	if err != nil {
+		t.Fatalf("GetIdentityValueVersion() error = %v", err)
+	}
+	if version.Value != "third" {
+		t.Errorf("GetIdentityValueVersion() of replaced value = %s, want third", version.Value)
+	}
+
+	if err := operator.DeleteValue(valueId); err != nil {
+		t.Fatalf("DeleteValue() error = %v", err)
+	}
+	if _, err := operator.GetValueByName("VALUES.a.b._version.4"); !errors.Is(err, api.ErrValueNotFound) {
+		t.Errorf("GetValueByName() of version after delete error = %v, want %v", err, api.ErrValueNotFound)
+	}
+}
+
+func TestValueHistoryNeedsVersionRights(t *testing.T) {
+	a, operator, vaultId := newTestVault(t, api.WithValueHistory(2))
+	ctx := context.Background()
+
+	valueId, err := operator.AddValue("VALUES.a.b", "secret", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	exact, err := operator.CreateIdentity("exact", rightInputs(t, "(rw)VALUES.a.b"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	versioned, err := operator.CreateIdentity("versioned", rightInputs(t, "(rw)VALUES.a.b", "(rwd)VALUES.a.b.>", "(r)VALUES.c.d"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	if err := operator.SyncValue(valueId); err != nil {
+		t.Fatalf("SyncValue() error = %v", err)
+	}
+	exactApi := a.GetProtectedApi(exact.PrivateKey, vaultId)
+	versionedApi := a.GetProtectedApi(versioned.PrivateKey, vaultId)
+
+	if _, err := exactApi.UpdateValue(valueId, "VALUES.a.b", "changed", api.ValueTypeString); err == nil {
+		t.Error("UpdateValue() without right for the versions succeeded")
+	}
+	if _, err := versionedApi.UpdateValue(valueId, "VALUES.a.b", "changed", api.ValueTypeString); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+	// the version is written before the value, it must be removed again if the update fails
+	if _, err := versionedApi.UpdateValue(valueId, "VALUES.c.d", "moved", api.ValueTypeString); err == nil {
+		t.Fatal("UpdateValue() to a name without write right succeeded")
+	}
+
+	versions, err := operator.ListValueVersionsContext(ctx, valueId)
+	if err != nil {
+		t.Fatalf("ListValueVersions() error = %v", err)
+	}
+	if len(versions) != 1 || versions[0].Version != 1 {
+		t.Errorf("ListValueVersions() = %+v, want only version 1", versions)
+	}
+	value, err := operator.GetIdentityValueById(valueId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value.Name != "VALUES.a.b" || value.Value != "changed" {
+		t.Errorf("GetIdentityValueById() = %s %s, want VALUES.a.b changed", value.Name, value.Value)
+	}
+}
+
+func TestEncryptOnlyForTrustedIdentities(t *testing.T) {
+	server := vaulttest.NewServer()
+	t.Cleanup(server.Close)
+	a := api.NewApi(server.URL, http.DefaultClient)
+	private, _, vaultId, err := a.NewVault("test", "token")
+	if err != nil {
+		t.Fatalf("NewVault() error = %v", err)
+	}
+	operator := a.GetProtectedApi(private, vaultId)
+
+	valueId, err := operator.AddValue("VALUES.a.b", "secret", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	reader, err := operator.CreateIdentity("reader", rightInputs(t, "(r)VALUES.a.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+
+	// the server hands out a key of an attacker for reader
+	attacker, _, err := helper.GenerateNewKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	attackerKey, err := helper.NewBase64PublicPem(&attacker.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Handler.SetPublicKey(reader.IdentityId, attackerKey); err != nil {
+		t.Fatal(err)
+	}
+
+	var untrusted *api.UntrustedIdentitiesError
+	_, err = operator.AddValue("VALUES.a.c", "other", api.ValueTypeString)
+	if !errors.As(err, &untrusted) || len(untrusted.Identities) != 1 || untrusted.Identities[0].IdentityId != reader.IdentityId {
+		t.Errorf("AddValue() error = %v, want untrusted identity %s", err, reader.IdentityId)
+	}
+	if _, err := operator.UpdateValue(valueId, "VALUES.a.b", "updated", api.ValueTypeString); !errors.Is(err, api.ErrUntrustedIdentity) {
+		t.Errorf("UpdateValue() error = %v, want %v", err, api.ErrUntrustedIdentity)
+	}
+	if err := operator.SyncValues(reader.IdentityId); !errors.Is(err, api.ErrUntrustedIdentity) {
+		t.Errorf("SyncValues() error = %v, want %v", err, api.ErrUntrustedIdentity)
+	}
+	if err := operator.SyncValue(valueId); !errors.Is(err, api.ErrUntrustedIdentity) {
+		t.Errorf("SyncValue() error = %v, want %v", err, api.ErrUntrustedIdentity)
+	}
+}
+
+func TestGetIdentityValuesByPattern(t *testing.T) {
+	_, operator, _ := newTestVault(t, api.WithValueHistory(1))
+	ctx := context.Background()
+
+	values := map[string]string{
+		"VALUES.prod.payments.key":     "key",
+		"VALUES.prod.payments.db.user": "user",
+		"VALUES.prod.payments.db.pass": "pass",
+		"VALUES.prod.shipping.key":     "other",
+		"VALUES.staging.payments.key":  "staging",
+	}
+	for name, value := range values {
+		if _, err := operator.AddValue(name, value, api.ValueTypeString); err != nil {
+			t.Fatalf("AddValue() error = %v", err)
+		}
+	}
+	current, err := operator.GetValueByName("VALUES.prod.payments.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := operator.UpdateValue(current.Id, "VALUES.prod.payments.key", "rotated", api.ValueTypeString); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+
+	got, err := operator.GetIdentityValuesByPatternContext(ctx, "VALUES.prod.payments.>")
+	if err != nil {
+		t.Fatalf("GetIdentityValuesByPattern() error = %v", err)
+	}
+	want := map[string]string{
+		"VALUES.prod.payments.key":     "rotated",
+		"VALUES.prod.payments.db.user": "user",
+		"VALUES.prod.payments.db.pass": "pass",
+	}
+	if len(got) != len(want) {
+		t.Errorf("GetIdentityValuesByPattern() returned %d values, want %d", len(got), len(want))
+	}
+	for name, value := range want {
+		if v, ok := got[name]; !ok || v.Value != value || v.Type != api.ValueTypeString {
+			t.Errorf("GetIdentityValuesByPattern()[%s] = %+v, want %s", name, v, value)
+		}
+	}
+
+	got, err = operator.GetIdentityValuesByPatternContext(ctx, "VALUES.*.payments.key")
+	if err != nil {
+		t.Fatalf("GetIdentityValuesByPattern() error = %v", err)
+	}
+	if len(got) != 2 || got["VALUES.staging.payments.key"] == nil {
+		t.Errorf("GetIdentityValuesByPattern() = %v, want prod and staging key", got)
+	}
+
+	if _, err := operator.GetIdentityValuesByPatternContext(ctx, "(r)VALUES.>"); !errors.Is(err, api.ErrInvalidRightPattern) {
+		t.Errorf("GetIdentityValuesByPattern() with right error = %v, want ErrInvalidRightPattern", err)
+	}
+}
+
+// cancelAfter returns a client whose requests cancel the returned context once the response of operation arrived
+func cancelAfter(operation string) (*http.Client, context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &http.Client{
+		Transport: api.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			resp, err := http.DefaultTransport.RoundTrip(req)
+			if strings.Contains(string(body), `"operationName":"`+operation+`"`) {
+				cancel()
+			}
+			return resp, err
+		}),
+	}, ctx, cancel
+}
+
+func TestCancelledWritesLeaveNoPartialPassframes(t *testing.T) {
+	server := vaulttest.NewServer()
+	t.Cleanup(server.Close)
+	a := api.NewApi(server.URL, http.DefaultClient)
+	private, _, vaultId, err := a.NewVault("test", "token")
+	if err != nil {
+		t.Fatalf("NewVault() error = %v", err)
+	}
+	operator := a.GetProtectedApi(private, vaultId)
+	reader, err := operator.CreateIdentity("reader", rightInputs(t, "(r)VALUES.a.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	readerApi := a.GetProtectedApi(reader.PrivateKey, vaultId)
+
+	// the value is created, but cancelled before any passframe is written
+	client, ctx, cancel := cancelAfter("addValue")
+	defer cancel()
+	if _, err := a.GetProtectedApiWithHttpClient(private, vaultId, client).AddValueContext(ctx, "VALUES.a.added", "secret", api.ValueTypeString); !errors.Is(err, context.Canceled) {
+		t.Errorf("AddValueContext() error = %v, want context.Canceled", err)
+	}
+	if _, err := operator.GetValueByName("VALUES.a.added"); !errors.Is(err, api.ErrValueNotFound) {
+		t.Errorf("GetValueByName() of cancelled add error = %v, want ErrValueNotFound", err)
+	}
+
+	// the value is renamed, but cancelled before its passframes are replaced
+	valueId, err := operator.AddValue("VALUES.a.updated", "old", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	client, ctx, cancel = cancelAfter("updateValue")
+	defer cancel()
+	if _, err := a.GetProtectedApiWithHttpClient(private, vaultId, client).UpdateValueContext(ctx, valueId, "VALUES.a.renamed", "new", api.ValueTypeString); !errors.Is(err, context.Canceled) {
+		t.Errorf("UpdateValueContext() error = %v, want context.Canceled", err)
+	}
+	for name, handler := range map[string]api.ProtectedApiHandler{"operator": operator, "reader": readerApi} {
+		value, err := handler.GetIdentityValueById(valueId)
+		if err != nil || value.Value != "old" || value.Name != "VALUES.a.updated" {
+			t.Errorf("GetIdentityValueById() by %s after cancelled update = %+v, %v, want old VALUES.a.updated", name, value, err)
+		}
+	}
+
+	// values are shared one by one, so each is either shared completely or not at all
+	secrets := map[string]string{"VALUES.b.x": "x", "VALUES.b.y": "y"}
+	for name, secret := range secrets {
+		if _, err := operator.AddValue(name, secret, api.ValueTypeString); err != nil {
+			t.Fatalf("AddValue() error = %v", err)
+		}
+	}
+	late, err := operator.CreateIdentity("late", rightInputs(t, "(r)VALUES.b.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	client, ctx, cancel = cancelAfter("addIdentityValue")
+	defer cancel()
+	if err := a.GetProtectedApiWithHttpClient(private, vaultId, client).SyncValuesContext(ctx, late.IdentityId); !errors.Is(err, context.Canceled) {
+		t.Errorf("SyncValuesContext() error = %v, want context.Canceled", err)
+	}
+	lateApi := a.GetProtectedApi(late.PrivateKey, vaultId)
+	shared := 0
+	for name, secret := range secrets {
+		value, err := lateApi.GetIdentityValueByName(name)
+		if err != nil {
+			continue
+		}
+		shared++
+		if value.Value != secret {
+			t.Errorf("GetIdentityValueByName(%s) after cancelled sync = %s, want %s", name, value.Value, secret)
+		}
+	}
+	if shared != 1 {
+		t.Errorf("cancelled sync shared %d values, want 1", shared)
+	}
+}
@@ -0,0 +1,339 @@
+package vaulttest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// object is the generic representation of an entity. Scalar fields are stored as is,
+// relations as func() any to resolve them lazily.
+type object map[string]any
+
+func resolve(v any) any {
+	if fn, ok := v.(func() any); ok {
+		return fn()
+	}
+	return v
+}
+
+// project reduces the resolved value v to the fields requested by the selection set
+func project(v any, set ast.SelectionSet) any {
+	v = resolve(v)
+	switch t := v.(type) {
+	case object:
+		if t == nil {
+			return nil
+		}
+		out := make(map[string]any)
+		for _, sel := range set {
+			field, ok := sel.(*ast.Field)
+			if !ok {
+				continue
+			}
+			if field.Name == "__typename" {
+				continue
+			}
+			key := field.Alias
+			if key == "" {
+				key = field.Name
+			}
+			out[key] = project(t[field.Name], field.SelectionSet)
+		}
+		return out
+	case []object:
+		out := make([]any, 0, len(t))
+		for _, o := range t {
+			out = append(out, project(o, set))
+		}
+		return out
+	default:
+		return t
+	}
+}
+
+func arguments(field *ast.Field, vars map[string]any) (map[string]any, error) {
+	args := make(map[string]any)
+	for _, arg := range field.Arguments {
+		v, err := arg.Value.Value(vars)
+		if err != nil {
+			return nil, err
+		}
+		args[arg.Name] = v
+	}
+	return args, nil
+}
+
+// asList implements the graphql input coercion of a single value to a list
+func asList(v any) []any {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		return t
+	default:
+		return []any{t}
+	}
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func asString(v any) (string, bool) {
+	s, ok := v.(string)
+	return s, ok
+}
+
+func asInt(v any) (int, bool) {
+	switch t := v.(type) {
+	case int:
+		return t, true
+	case int64:
+		return int(t), true
+	case float64:
+		return int(t), true
+	}
+	return 0, false
+}
+
+// matchFilter evaluates a graphql filter input like ValueFiltersInput against obj.
+// Unset (nil) filter entries are ignored, relation filters match if any related entity matches.
+func matchFilter(obj object, filter map[string]any) (bool, error) {
+	for key, raw := range filter {
+		if raw == nil {
+			continue
+		}
+		switch key {
+		case "and":
+			for _, sub := range asList(raw) {
+				if sub == nil {
+					continue
+				}
+				ok, err := matchFilter(obj, asMap(sub))
+				if err != nil || !ok {
+					return false, err
+				}
+			}
+		case "or":
+			anyMatch := false
+			for _, sub := range asList(raw) {
+				if sub == nil {
+					continue
+				}
+				ok, err := matchFilter(obj, asMap(sub))
+				if err != nil {
+					return false, err
+				}
+				anyMatch = anyMatch || ok
+			}
+			if !anyMatch {
+				return false, nil
+			}
+		case "not":
+			ok, err := matchFilter(obj, asMap(raw))
+			if err != nil || ok {
+				return false, err
+			}
+		default:
+			ok, err := matchField(resolve(obj[key]), asMap(raw))
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+func matchField(value any, filter map[string]any) (bool, error) {
+	switch t := value.(type) {
+	case object:
+		if t == nil {
+			return false, nil
+		}
+		return matchFilter(t, filter)
+	case []object:
+		for _, o := range t {
+			ok, err := matchFilter(o, filter)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+	for op, raw := range filter {
+		if raw == nil {
+			continue
+		}
+		ok, err := matchScalar(value, op, raw)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchScalar(value any, op string, raw any) (bool, error) {
+	switch op {
+	case "null":
+		b, _ := raw.(bool)
+		return b == isNull(value), nil
+	case "notNull":
+		b, _ := raw.(bool)
+		return b != isNull(value), nil
+	case "and":
+		for _, v := range asList(raw) {
+			if v != nil && compare(value, v) != 0 {
+				return false, nil
+			}
+		}
+		return true, nil
+	case "or", "in":
+		for _, v := range asList(raw) {
+			if v != nil && compare(value, v) == 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	case "notin", "notIn":
+		for _, v := range asList(raw) {
+			if v != nil && compare(value, v) == 0 {
+				return false, nil
+			}
+		}
+		return true, nil
+	case "not":
+		ok, err := matchField(value, asMap(raw))
+		return !ok, err
+	case "eq":
+		return compare(value, raw) == 0, nil
+	case "ne":
+		return compare(value, raw) != 0, nil
+	case "gt":
+		return !isNull(value) && compare(value, raw) > 0, nil
+	case "gte":
+		return !isNull(value) && compare(value, raw) >= 0, nil
+	case "lt":
+		return !isNull(value) && compare(value, raw) < 0, nil
+	case "lte":
+		return !isNull(value) && compare(value, raw) <= 0, nil
+	case "between":
+		between := asMap(raw)
+		return !isNull(value) && compare(value, between["start"]) > 0 && compare(value, between["end"]) < 0, nil
+	}
+	s := fmt.Sprint(value)
+	arg, _ := asString(raw)
+	switch op {
+	case "eqi":
+		return strings.EqualFold(s, arg), nil
+	case "startsWith":
+		return strings.HasPrefix(s, arg), nil
+	case "endsWith":
+		return strings.HasSuffix(s, arg), nil
+	case "contains":
+		return strings.Contains(s, arg), nil
+	case "notContains":
+		return !strings.Contains(s, arg), nil
+	case "containsi":
+		return strings.Contains(strings.ToLower(s), strings.ToLower(arg)), nil
+	case "notContainsi":
+		return !strings.Contains(strings.ToLower(s), strings.ToLower(arg)), nil
+	}
+	return false, fmt.Errorf("filter operation %s is not supported", op)
+}
+
+func isNull(value any) bool {
+	switch t := value.(type) {
+	case nil:
+		return true
+	case *time.Time:
+		return t == nil
+	}
+	return false
+}
+
+// compare compares an entity field with a filter argument and returns -1, 0 or 1
+func compare(value any, arg any) int {
+	switch t := value.(type) {
+	case *time.Time:
+		if t == nil {
+			return -1
+		}
+		s, _ := asString(arg)
+		other, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return -1
+		}
+		return t.Compare(other)
+	case bool:
+		b, _ := arg.(bool)
+		if t == b {
+			return 0
+		}
+		return -1
+	case int:
+		i, _ := asInt(arg)
+		switch {
+		case t < i:
+			return -1
+		case t > i:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(value), fmt.Sprint(arg))
+}
+
+func compareFields(a, b any) int {
+	if ta, ok := a.(*time.Time); ok && ta != nil {
+		if tb, ok := b.(*time.Time); ok && tb != nil {
+			return ta.Compare(*tb)
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// queryResult applies filter, order and paging arguments of a query* field
+func queryResult(objects []object, args map[string]any) (object, error) {
+	filtered := make([]object, 0, len(objects))
+	for _, o := range objects {
+		ok, err := matchFilter(o, asMap(args["filter"]))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, o)
+		}
+	}
+	if order := asMap(args["order"]); order != nil {
+		if field, ok := asString(order["asc"]); ok {
+			sort.SliceStable(filtered, func(i, j int) bool {
+				return compareFields(resolve(filtered[i][field]), resolve(filtered[j][field])) < 0
+			})
+		}
+		if field, ok := asString(order["desc"]); ok {
+			sort.SliceStable(filtered, func(i, j int) bool {
+				return compareFields(resolve(filtered[i][field]), resolve(filtered[j][field])) > 0
+			})
+		}
+	}
+	totalCount := len(filtered)
+	if offset, ok := asInt(args["offset"]); ok && offset > 0 {
+		if offset > len(filtered) {
+			offset = len(filtered)
+		}
+		filtered = filtered[offset:]
+	}
+	if first, ok := asInt(args["first"]); ok && first >= 0 && first < len(filtered) {
+		filtered = filtered[:first]
+	}
+	return object{
+		"data":       filtered,
+		"count":      len(filtered),
+		"totalCount": totalCount,
+	}, nil
+}
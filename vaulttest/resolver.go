@@ -0,0 +1,642 @@
+package vaulttest
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/cryptvault-cloud/helper"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	targetValues     = "values"
+	targetIdentities = "identities"
+	targetSystem     = "system"
+
+	directionRead   = "read"
+	directionWrite  = "write"
+	directionDelete = "delete"
+)
+
+var rightPatternRegex = regexp.MustCompile(`^(VALUES|IDENTITY|SYSTEM)(\.([\w\-]+|[>\*]))+$`)
+
+func (h *Handler) resolve(caller *identity, operation ast.Operation, name string, args map[string]any) (any, error) {
+	if operation == ast.Query {
+		switch name {
+		case "identitiesWithValueAccess":
+			return h.identitiesWithValueAccess(caller, args)
+		case "allRelatedValues":
+			return h.allRelatedValues(caller, args)
+		case "getIdentity":
+			return h.getIdentity(caller, args)
+		case "queryIdentity":
+			return h.queryIdentity(caller, args)
+		case "getValue":
+			return h.getValue(caller, args)
+		case "queryValue":
+			return h.queryValue(caller, args)
+		case "queryIdentityValue":
+			return h.queryIdentityValue(caller, args)
+		case "getVault":
+			return h.getVault(caller, args)
+		}
+		return nil, fmt.Errorf("query %s is not supported by vaulttest", name)
+	}
+	switch name {
+	case "createVault":
+		return h.createVault(args)
+	case "addIdentity":
+		return h.addIdentity(caller, args)
+	case "updateIdentity":
+		return h.updateIdentity(caller, args)
+	case "deleteIdentity":
+		return h.deleteIdentity(caller, args)
+	case "addRight":
+		return h.addRight(caller, args)
+	case "deleteRight":
+		return h.deleteRight(caller, args)
+	case "addValue":
+		return h.addValue(caller, args)
+	case "updateValue":
+		return h.updateValue(caller, args)
+	case "deleteValue":
+		return h.deleteValue(caller, args)
+	case "addIdentityValue":
+		return h.addIdentityValue(caller, args)
+	case "updateIdentityValue":
+		return h.updateIdentityValue(caller, args)
+	case "deleteIdentityValue":
+		return h.deleteIdentityValue(caller, args)
+	case "updateVault":
+		return h.updateVault(caller, args)
+	case "deleteVault":
+		return h.deleteVault(caller, args)
+	}
+	return nil, fmt.Errorf("mutation %s is not supported by vaulttest", name)
+}
+
+func (h *Handler) require(caller *identity, target, direction, resource string) error {
+	if !h.store.hasRight(caller.id, target, direction, resource) {
+		return fmt.Errorf("%w: %s right for %s %s missing", errPermissionDenied, direction, target, resource)
+	}
+	return nil
+}
+
+func (h *Handler) canReadIdentity(caller *identity, i *identity) bool {
+	return caller.id == i.id || h.store.hasRight(caller.id, targetIdentities, directionRead, identityResource(i.id))
+}
+
+func (h *Handler) identityOfVault(caller *identity, id string) (*identity, error) {
+	i, ok := h.store.identities[id]
+	if !ok || i.vaultId != caller.vaultId {
+		return nil, fmt.Errorf("identity %s not found", id)
+	}
+	return i, nil
+}
+
+func (h *Handler) valueOfVault(caller *identity, id string) (*value, error) {
+	v, ok := h.store.values[id]
+	if !ok || v.vaultId != caller.vaultId {
+		return nil, fmt.Errorf("value %s not found", id)
+	}
+	return v, nil
+}
+
+func payload(affected []object) object {
+	return object{"affected": affected, "count": len(affected)}
+}
+
+func deletePayload(count int) object {
+	return object{"count": count, "msg": nil}
+}
+
+// filterObjects returns the objects matching the filter argument of a mutation
+func filterObjects(objects []object, filter any) ([]object, error) {
+	result := make([]object, 0)
+	for _, o := range objects {
+		ok, err := matchFilter(o, asMap(filter))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, o)
+		}
+	}
+	return result, nil
+}
+
+func (h *Handler) identitiesWithValueAccess(caller *identity, args map[string]any) (any, error) {
+	name, _ := asString(args["forValue"])
+	resource := valueResource(name)
+	if !h.store.hasRight(caller.id, targetValues, directionRead, resource) && !h.store.hasRight(caller.id, targetValues, directionWrite, resource) {
+		return nil, fmt.Errorf("%w: no right for values %s", errPermissionDenied, resource)
+	}
+	result := make([]*identity, 0)
+	for _, i := range h.store.identitiesOfVault(caller.vaultId) {
+		if h.store.hasRight(i.id, targetValues, directionRead, resource) {
+			result = append(result, i)
+		}
+	}
+	return h.store.identityObjects(result), nil
+}
+
+func (h *Handler) allRelatedValues(caller *identity, args map[string]any) (any, error) {
+	id, _ := asString(args["identityId"])
+	i, err := h.identityOfVault(caller, id)
+	if err != nil {
+		return nil, err
+	}
+	if !h.canReadIdentity(caller, i) {
+		return nil, fmt.Errorf("%w: can not read identity %s", errPermissionDenied, id)
+	}
+	result := make([]*value, 0)
+	for _, v := range h.store.valuesOfVault(caller.vaultId) {
+		if h.store.hasRight(i.id, targetValues, directionRead, valueResource(v.name)) {
+			result = append(result, v)
+		}
+	}
+	return h.store.valueObjects(result), nil
+}
+
+func (h *Handler) getIdentity(caller *identity, args map[string]any) (any, error) {
+	id, _ := asString(args["id"])
+	i, ok := h.store.identities[id]
+	if !ok || i.vaultId != caller.vaultId {
+		return object(nil), nil
+	}
+	if !h.canReadIdentity(caller, i) {
+		return nil, fmt.Errorf("%w: can not read identity %s", errPermissionDenied, id)
+	}
+	return h.store.identityObject(i), nil
+}
+
+func (h *Handler) queryIdentity(caller *identity, args map[string]any) (any, error) {
+	visible := make([]*identity, 0)
+	for _, i := range h.store.identitiesOfVault(caller.vaultId) {
+		if h.canReadIdentity(caller, i) {
+			visible = append(visible, i)
+		}
+	}
+	return queryResult(h.store.identityObjects(visible), args)
+}
+
+func (h *Handler) getValue(caller *identity, args map[string]any) (any, error) {
+	id, _ := asString(args["id"])
+	v, ok := h.store.values[id]
+	if !ok || v.vaultId != caller.vaultId {
+		return object(nil), nil
+	}
+	if err := h.require(caller, targetValues, directionRead, valueResource(v.name)); err != nil {
+		return nil, err
+	}
+	return h.store.valueObject(v), nil
+}
+
+func (h *Handler) readableValues(caller *identity) []*value {
+	result := make([]*value, 0)
+	for _, v := range h.store.valuesOfVault(caller.vaultId) {
+		if h.store.hasRight(caller.id, targetValues, directionRead, valueResource(v.name)) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func (h *Handler) queryValue(caller *identity, args map[string]any) (any, error) {
+	return queryResult(h.store.valueObjects(h.readableValues(caller)), args)
+}
+
+func (h *Handler) queryIdentityValue(caller *identity, args map[string]any) (any, error) {
+	result := make([]*identityValue, 0)
+	for _, v := range h.readableValues(caller) {
+		result = append(result, h.store.identityValuesOfValue(v.id)...)
+	}
+	return queryResult(h.store.identityValueObjects(result), args)
+}
+
+func (h *Handler) getVault(caller *identity, args map[string]any) (any, error) {
+	id, _ := asString(args["id"])
+	if id != caller.vaultId {
+		return object(nil), nil
+	}
+	return h.store.vaultObject(h.store.vaults[id]), nil
+}
+
+func (h *Handler) createVault(args map[string]any) (any, error) {
+	name, _ := asString(args["name"])
+	token, _ := asString(args["token"])
+	publicKey, _ := asString(args["operatorPublicKey"])
+	key := helper.Base64PublicPem(publicKey)
+	now := h.store.now()
+	v := &vault{id: newId(), name: name, tokenId: token, createdAt: now, updatedAt: now}
+	operatorId, err := key.GetIdentityId(v.id)
+	if err != nil {
+		return nil, err
+	}
+	h.store.vaults[v.id] = v
+	h.store.identities[operatorId] = &identity{
+		id:         operatorId,
+		name:       "operator",
+		publicKey:  key,
+		vaultId:    v.id,
+		isOperator: true,
+		createdAt:  now,
+		updatedAt:  now,
+	}
+	for target, pattern := range map[string]string{
+		targetValues:     helper.ValuesPrefix + ">",
+		targetIdentities: helper.IdentityPrefix + ">",
+		targetSystem:     helper.SystemPrefix + ">",
+	} {
+		for _, direction := range []string{directionRead, directionWrite, directionDelete} {
+			r := &right{id: newId(), target: target, right: direction, rightValuePattern: pattern, identityId: operatorId, createdAt: now, updatedAt: now}
+			h.store.rights[r.id] = r
+		}
+	}
+	return v.id, nil
+}
+
+func (h *Handler) addIdentity(caller *identity, args map[string]any) (any, error) {
+	inputs := asList(args["input"])
+	added := make([]*identity, 0, len(inputs))
+	for _, raw := range inputs {
+		input := asMap(raw)
+		publicKey, _ := asString(input["publicKey"])
+		key := helper.Base64PublicPem(publicKey)
+		id, err := key.GetIdentityId(caller.vaultId)
+		if err != nil {
+			return nil, err
+		}
+		if err := h.require(caller, targetIdentities, directionWrite, identityResource(id)); err != nil {
+			return nil, err
+		}
+		if _, ok := h.store.identities[id]; ok {
+			return nil, fmt.Errorf("identity %s already exists", id)
+		}
+		creatorVerification, _ := asString(input["creatorVerification"])
+		if err := h.verifyCreator(caller, id, creatorVerification); err != nil {
+			return nil, err
+		}
+		name, _ := asString(input["name"])
+		now := h.store.now()
+		added = append(added, &identity{
+			id:                  id,
+			name:                name,
+			publicKey:           key,
+			vaultId:             caller.vaultId,
+			creatorVerification: creatorVerification,
+			createdAt:           now,
+			updatedAt:           now,
+		})
+	}
+	for _, i := range added {
+		h.store.identities[i.id] = i
+	}
+	return payload(h.store.identityObjects(added)), nil
+}
+
+// verifyCreator checks that the creator verification of identity id is signed by the caller
+func (h *Handler) verifyCreator(caller *identity, id, creatorVerification string) error {
+	message, messageJson, err := helper.DecodeCreatorJWT(creatorVerification)
+	if err != nil {
+		return err
+	}
+	if message.CreatorTokenId != caller.id || message.TokenId != id || message.VaultId != caller.vaultId {
+		return errors.New("creator verification does not match creator and identity")
+	}
+	return verifySignature(caller.publicKey, messageJson, creatorVerification)
+}
+
+// verifyCreatorOf checks that the creator verification of identity id is signed by the creator it names
+func (h *Handler) verifyCreatorOf(caller *identity, id, creatorVerification string) error {
+	message, _, err := helper.DecodeCreatorJWT(creatorVerification)
+	if err != nil {
+		return err
+	}
+	creator, err := h.identityOfVault(caller, message.CreatorTokenId)
+	if err != nil {
+		return err
+	}
+	return h.verifyCreator(creator, id, creatorVerification)
+}
+
+func (h *Handler) updateIdentity(caller *identity, args map[string]any) (any, error) {
+	input := asMap(args["input"])
+	matched, err := filterObjects(h.store.identityObjects(h.store.identitiesOfVault(caller.vaultId)), input["filter"])
+	if err != nil {
+		return nil, err
+	}
+	set := asMap(input["set"])
+	if set["publicKey"] != nil {
+		return nil, errors.New("publicKey of an identity can not be changed")
+	}
+	for _, o := range matched {
+		if err := h.require(caller, targetIdentities, directionWrite, identityResource(o["id"].(string))); err != nil {
+			return nil, err
+		}
+	}
+	updated := make([]*identity, 0, len(matched))
+	for _, o := range matched {
+		i := h.store.identities[o["id"].(string)]
+		if name, ok := asString(set["name"]); ok {
+			i.name = name
+		}
+		if creatorVerification, ok := asString(set["creatorVerification"]); ok {
+			if err := h.verifyCreatorOf(caller, i.id, creatorVerification); err != nil {
+				return nil, err
+			}
+			i.creatorVerification = creatorVerification
+		}
+		i.updatedAt = h.store.now()
+		updated = append(updated, i)
+	}
+	return payload(h.store.identityObjects(updated)), nil
+}
+
+func (h *Handler) deleteIdentity(caller *identity, args map[string]any) (any, error) {
+	matched, err := filterObjects(h.store.identityObjects(h.store.identitiesOfVault(caller.vaultId)), args["filter"])
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range matched {
+		if err := h.require(caller, targetIdentities, directionDelete, identityResource(o["id"].(string))); err != nil {
+			return nil, err
+		}
+	}
+	for _, o := range matched {
+		h.store.deleteIdentity(o["id"].(string))
+	}
+	return deletePayload(len(matched)), nil
+}
+
+func (h *Handler) addRight(caller *identity, args map[string]any) (any, error) {
+	inputs := asList(args["input"])
+	added := make([]*right, 0, len(inputs))
+	for _, raw := range inputs {
+		input := asMap(raw)
+		identityId, _ := asString(input["identityID"])
+		if _, err := h.identityOfVault(caller, identityId); err != nil {
+			return nil, err
+		}
+		if err := h.require(caller, targetIdentities, directionWrite, identityResource(identityId)); err != nil {
+			return nil, err
+		}
+		target, _ := asString(input["target"])
+		direction, _ := asString(input["right"])
+		pattern, _ := asString(input["rightValuePattern"])
+		if !rightPatternRegex.MatchString(pattern) {
+			return nil, fmt.Errorf("invalid right value pattern %s", pattern)
+		}
+		if !h.store.coversPattern(caller.id, target, direction, pattern) {
+			return nil, fmt.Errorf("%w: can not grant %s right for %s %s which the creator does not have", errPermissionDenied, direction, target, pattern)
+		}
+		now := h.store.now()
+		added = append(added, &right{
+			id:                newId(),
+			target:            target,
+			right:             direction,
+			rightValuePattern: pattern,
+			identityId:        identityId,
+			createdAt:         now,
+			updatedAt:         now,
+		})
+	}
+	for _, r := range added {
+		h.store.rights[r.id] = r
+	}
+	return payload(h.store.rightObjects(added)), nil
+}
+
+func (h *Handler) deleteRight(caller *identity, args map[string]any) (any, error) {
+	rights := make([]*right, 0)
+	for _, i := range h.store.identitiesOfVault(caller.vaultId) {
+		rights = append(rights, h.store.rightsOfIdentity(i.id)...)
+	}
+	matched, err := filterObjects(h.store.rightObjects(rights), args["filter"])
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range matched {
+		if err := h.require(caller, targetIdentities, directionWrite, identityResource(o["identityID"].(string))); err != nil {
+			return nil, err
+		}
+	}
+	for _, o := range matched {
+		delete(h.store.rights, o["id"].(string))
+	}
+	return deletePayload(len(matched)), nil
+}
+
+func (h *Handler) addValue(caller *identity, args map[string]any) (any, error) {
+	inputs := asList(args["input"])
+	added := make([]*value, 0, len(inputs))
+	for _, raw := range inputs {
+		input := asMap(raw)
+		name, _ := asString(input["name"])
+		valueType, _ := asString(input["type"])
+		if err := h.require(caller, targetValues, directionWrite, valueResource(name)); err != nil {
+			return nil, err
+		}
+		for _, v := range h.store.valuesOfVault(caller.vaultId) {
+			if v.name == name {
+				return nil, fmt.Errorf("value %s already exists", name)
+			}
+		}
+		now := h.store.now()
+		added = append(added, &value{id: newId(), name: name, vaultId: caller.vaultId, valueType: valueType, createdAt: now, updatedAt: now})
+	}
+	for _, v := range added {
+		h.store.values[v.id] = v
+	}
+	return payload(h.store.valueObjects(added)), nil
+}
+
+func (h *Handler) updateValue(caller *identity, args map[string]any) (any, error) {
+	input := asMap(args["input"])
+	matched, err := filterObjects(h.store.valueObjects(h.store.valuesOfVault(caller.vaultId)), input["filter"])
+	if err != nil {
+		return nil, err
+	}
+	set := asMap(input["set"])
+	newName, rename := asString(set["name"])
+	for _, o := range matched {
+		if err := h.require(caller, targetValues, directionWrite, valueResource(o["name"].(string))); err != nil {
+			return nil, err
+		}
+		if rename {
+			if err := h.require(caller, targetValues, directionWrite, valueResource(newName)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	updated := make([]*value, 0, len(matched))
+	for _, o := range matched {
+		v := h.store.values[o["id"].(string)]
+		if rename {
+			v.name = newName
+		}
+		if valueType, ok := asString(set["type"]); ok {
+			v.valueType = valueType
+		}
+		v.updatedAt = h.store.now()
+		updated = append(updated, v)
+	}
+	return payload(h.store.valueObjects(updated)), nil
+}
+
+func (h *Handler) deleteValue(caller *identity, args map[string]any) (any, error) {
+	matched, err := filterObjects(h.store.valueObjects(h.store.valuesOfVault(caller.vaultId)), args["filter"])
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range matched {
+		if err := h.require(caller, targetValues, directionDelete, valueResource(o["name"].(string))); err != nil {
+			return nil, err
+		}
+	}
+	for _, o := range matched {
+		h.store.deleteValue(o["id"].(string))
+	}
+	return deletePayload(len(matched)), nil
+}
+
+func (h *Handler) addIdentityValue(caller *identity, args map[string]any) (any, error) {
+	inputs := asList(args["input"])
+	added := make([]*identityValue, 0, len(inputs))
+	for _, raw := range inputs {
+		input := asMap(raw)
+		valueId, _ := asString(input["valueID"])
+		identityId, _ := asString(input["identityID"])
+		passframe, _ := asString(input["passframe"])
+		if err := h.checkIdentityValue(caller, valueId, identityId); err != nil {
+			return nil, err
+		}
+		for _, iv := range h.store.identityValuesOfValue(valueId) {
+			if iv.identityId == identityId {
+				return nil, fmt.Errorf("identity value for value %s and identity %s already exists", valueId, identityId)
+			}
+		}
+		for _, iv := range added {
+			if iv.valueId == valueId && iv.identityId == identityId {
+				return nil, fmt.Errorf("identity value for value %s and identity %s added twice", valueId, identityId)
+			}
+		}
+		now := h.store.now()
+		added = append(added, &identityValue{id: newId(), valueId: valueId, identityId: identityId, passframe: passframe, createdAt: now, updatedAt: now})
+	}
+	for _, iv := range added {
+		h.store.identityValues[iv.id] = iv
+		h.store.values[iv.valueId].updatedAt = iv.updatedAt
+	}
+	return payload(h.store.identityValueObjects(added)), nil
+}
+
+// checkIdentityValue checks that the caller can write the value and the identity can read it
+func (h *Handler) checkIdentityValue(caller *identity, valueId, identityId string) error {
+	v, err := h.valueOfVault(caller, valueId)
+	if err != nil {
+		return err
+	}
+	if err := h.require(caller, targetValues, directionWrite, valueResource(v.name)); err != nil {
+		return err
+	}
+	if _, err := h.identityOfVault(caller, identityId); err != nil {
+		return err
+	}
+	if !h.store.hasRight(identityId, targetValues, directionRead, valueResource(v.name)) {
+		return fmt.Errorf("%w: identity %s can not read value %s", errPermissionDenied, identityId, v.name)
+	}
+	return nil
+}
+
+func (h *Handler) updateIdentityValue(caller *identity, args map[string]any) (any, error) {
+	input := asMap(args["input"])
+	matched, err := filterObjects(h.store.identityValueObjects(h.store.identityValuesOfVault(caller.vaultId)), input["filter"])
+	if err != nil {
+		return nil, err
+	}
+	set := asMap(input["set"])
+	for _, o := range matched {
+		iv := h.store.identityValues[o["id"].(string)]
+		valueId, identityId := iv.valueId, iv.identityId
+		if s, ok := asString(set["valueID"]); ok {
+			valueId = s
+		}
+		if s, ok := asString(set["identityID"]); ok {
+			identityId = s
+		}
+		if err := h.checkIdentityValue(caller, valueId, identityId); err != nil {
+			return nil, err
+		}
+	}
+	updated := make([]*identityValue, 0, len(matched))
+	for _, o := range matched {
+		iv := h.store.identityValues[o["id"].(string)]
+		if s, ok := asString(set["valueID"]); ok {
+			iv.valueId = s
+		}
+		if s, ok := asString(set["identityID"]); ok {
+			iv.identityId = s
+		}
+		if s, ok := asString(set["passframe"]); ok {
+			iv.passframe = s
+		}
+		iv.updatedAt = h.store.now()
+		h.store.values[iv.valueId].updatedAt = iv.updatedAt
+		updated = append(updated, iv)
+	}
+	return payload(h.store.identityValueObjects(updated)), nil
+}
+
+func (h *Handler) deleteIdentityValue(caller *identity, args map[string]any) (any, error) {
+	matched, err := filterObjects(h.store.identityValueObjects(h.store.identityValuesOfVault(caller.vaultId)), args["filter"])
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range matched {
+		v := h.store.values[o["valueID"].(string)]
+		if err := h.require(caller, targetValues, directionWrite, valueResource(v.name)); err != nil {
+			return nil, err
+		}
+	}
+	for _, o := range matched {
+		delete(h.store.identityValues, o["id"].(string))
+	}
+	return deletePayload(len(matched)), nil
+}
+
+func (h *Handler) updateVault(caller *identity, args map[string]any) (any, error) {
+	if err := h.require(caller, targetSystem, directionWrite, vaultResource); err != nil {
+		return nil, err
+	}
+	input := asMap(args["input"])
+	v := h.store.vaults[caller.vaultId]
+	matched, err := filterObjects([]object{h.store.vaultObject(v)}, input["filter"])
+	if err != nil || len(matched) == 0 {
+		return payload(matched), err
+	}
+	if name, ok := asString(asMap(input["set"])["name"]); ok {
+		v.name = name
+	}
+	v.updatedAt = h.store.now()
+	return payload([]object{h.store.vaultObject(v)}), nil
+}
+
+func (h *Handler) deleteVault(caller *identity, args map[string]any) (any, error) {
+	if err := h.require(caller, targetSystem, directionDelete, vaultResource); err != nil {
+		return nil, err
+	}
+	matched, err := filterObjects([]object{h.store.vaultObject(h.store.vaults[caller.vaultId])}, args["filter"])
+	if err != nil || len(matched) == 0 {
+		return deletePayload(0), err
+	}
+	for _, v := range h.store.valuesOfVault(caller.vaultId) {
+		h.store.deleteValue(v.id)
+	}
+	for _, i := range h.store.identitiesOfVault(caller.vaultId) {
+		h.store.deleteIdentity(i.id)
+	}
+	delete(h.store.vaults, caller.vaultId)
+	return deletePayload(1), nil
+}
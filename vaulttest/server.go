@@ -0,0 +1,187 @@
+// Package vaulttest provides an in-memory CryptVault graphql server to run tests of the api
+// package without the hosted service.
+//
+// The server implements the subset of schema.graphql used by the api client, verifies the
+// JWT of every authenticated request and enforces the right patterns of the calling identity.
+package vaulttest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cryptvault-cloud/helper"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+var (
+	errUnauthenticated  = errors.New("unauthenticated")
+	errTokenExpired     = errors.New("token expired")
+	errPermissionDenied = errors.New("permission denied")
+)
+
+// Server is an in-memory CryptVault graphql server running at URL
+type Server struct {
+	*httptest.Server
+	Handler *Handler
+}
+
+// NewServer starts a new Server, it must be closed by the caller
+func NewServer() *Server {
+	h := NewHandler()
+	return &Server{
+		Server:  httptest.NewServer(h),
+		Handler: h,
+	}
+}
+
+// Handler is the http.Handler serving the graphql endpoint
+type Handler struct {
+	mu    sync.Mutex
+	store *store
+	// Now is used for all timestamps and the JWT expiry check
+	Now func() time.Time
+}
+
+func NewHandler() *Handler {
+	h := &Handler{Now: time.Now}
+	h.store = newStore(func() time.Time { return h.Now().UTC() })
+	return h
+}
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type response struct {
+	Data   map[string]any `json:"data"`
+	Errors gqlerror.List  `json:"errors,omitempty"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	doc, err := parser.ParseQuery(&ast.Source{Input: req.Query})
+	if err != nil {
+		writeResponse(w, http.StatusOK, response{Errors: gqlerror.List{gqlerror.Wrap(err)}})
+		return
+	}
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		writeResponse(w, http.StatusOK, response{Errors: gqlerror.List{gqlerror.Errorf("operation %s not found", req.OperationName)}})
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	caller, err := h.authenticate(r.Header.Get("Authorization"))
+	if err != nil && !(op.Operation == ast.Mutation && onlyCreateVault(op)) {
+		writeResponse(w, http.StatusUnauthorized, response{Errors: gqlerror.List{{
+			Message:    err.Error(),
+			Extensions: map[string]any{"code": "UNAUTHENTICATED"},
+		}}})
+		return
+	}
+
+	resp := response{Data: make(map[string]any)}
+	for _, sel := range op.SelectionSet {
+		field, ok := sel.(*ast.Field)
+		if !ok {
+			continue
+		}
+		key := field.Alias
+		if key == "" {
+			key = field.Name
+		}
+		args, err := arguments(field, req.Variables)
+		if err == nil {
+			var result any
+			result, err = h.resolve(caller, op.Operation, field.Name, args)
+			if err == nil {
+				resp.Data[key] = project(result, field.SelectionSet)
+				continue
+			}
+		}
+		resp.Data[key] = nil
+		resp.Errors = append(resp.Errors, &gqlerror.Error{
+			Message: err.Error(),
+			Path:    ast.Path{ast.PathName(key)},
+		})
+	}
+	writeResponse(w, http.StatusOK, resp)
+}
+
+func onlyCreateVault(op *ast.OperationDefinition) bool {
+	for _, sel := range op.SelectionSet {
+		if field, ok := sel.(*ast.Field); !ok || field.Name != "createVault" {
+			return false
+		}
+	}
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// authenticate verifies the bearer JWT and returns the signing identity
+func (h *Handler) authenticate(header string) (*identity, error) {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return nil, errUnauthenticated
+	}
+	message, messageJson, err := helper.DecodeJWT(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errUnauthenticated, err)
+	}
+	caller, ok := h.store.identities[message.TokenId]
+	if !ok || caller.vaultId != message.VaultId {
+		return nil, errUnauthenticated
+	}
+	if err := verifySignature(caller.publicKey, messageJson, token); err != nil {
+		return nil, err
+	}
+	if !message.Expired.After(h.Now()) {
+		return nil, errTokenExpired
+	}
+	return caller, nil
+}
+
+// verifySignature checks the signature part of a JWT signed by helper.SignJWT or helper.SignCreatorJWT
+func verifySignature(publicKey helper.Base64PublicPem, messageJson, token string) error {
+	key, err := publicKey.GetPublicKey()
+	if err != nil {
+		return err
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: invalid jwt", errUnauthenticated)
+	}
+	ok, err := helper.Verify(key, messageJson, parts[2])
+	if err != nil {
+		return fmt.Errorf("%w: %w", errUnauthenticated, err)
+	}
+	if !ok {
+		return fmt.Errorf("%w: invalid signature", errUnauthenticated)
+	}
+	return nil
+}
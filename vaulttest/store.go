@@ -0,0 +1,377 @@
+package vaulttest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+type vault struct {
+	id        string
+	name      string
+	tokenId   string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type identity struct {
+	id                  string
+	name                string
+	publicKey           helper.Base64PublicPem
+	vaultId             string
+	creatorVerification string
+	isOperator          bool
+	createdAt           time.Time
+	updatedAt           time.Time
+}
+
+type right struct {
+	id                string
+	target            string
+	right             string
+	rightValuePattern string
+	identityId        string
+	createdAt         time.Time
+	updatedAt         time.Time
+}
+
+type value struct {
+	id        string
+	name      string
+	vaultId   string
+	valueType string
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type identityValue struct {
+	id         string
+	valueId    string
+	identityId string
+	passframe  string
+	createdAt  time.Time
+	updatedAt  time.Time
+}
+
+// store keeps all entities of all vaults in memory. It is not safe for concurrent use,
+// the Server serializes all requests.
+type store struct {
+	now            func() time.Time
+	vaults         map[string]*vault
+	identities     map[string]*identity
+	rights         map[string]*right
+	values         map[string]*value
+	identityValues map[string]*identityValue
+}
+
+func newStore(now func() time.Time) *store {
+	return &store{
+		now:            now,
+		vaults:         make(map[string]*vault),
+		identities:     make(map[string]*identity),
+		rights:         make(map[string]*right),
+		values:         make(map[string]*value),
+		identityValues: make(map[string]*identityValue),
+	}
+}
+
+func newId() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func (s *store) vaultObject(v *vault) object {
+	if v == nil {
+		return nil
+	}
+	return object{
+		"id":        v.id,
+		"name":      v.name,
+		"tokenID":   v.tokenId,
+		"createdAt": timePtr(v.createdAt),
+		"updatedAt": timePtr(v.updatedAt),
+		"identities": func() any {
+			return s.identityObjects(s.identitiesOfVault(v.id))
+		},
+		"values": func() any {
+			return s.valueObjects(s.valuesOfVault(v.id))
+		},
+	}
+}
+
+func (s *store) identityObject(i *identity) object {
+	if i == nil {
+		return nil
+	}
+	return object{
+		"id":                  i.id,
+		"name":                i.name,
+		"publicKey":           string(i.publicKey),
+		"vaultID":             i.vaultId,
+		"creatorVerification": i.creatorVerification,
+		"isOperator":          i.isOperator,
+		"createdAt":           timePtr(i.createdAt),
+		"updatedAt":           timePtr(i.updatedAt),
+		"vault": func() any {
+			return s.vaultObject(s.vaults[i.vaultId])
+		},
+		"rights": func() any {
+			return s.rightObjects(s.rightsOfIdentity(i.id))
+		},
+	}
+}
+
+func (s *store) identityObjects(identities []*identity) []object {
+	result := make([]object, 0, len(identities))
+	for _, v := range identities {
+		result = append(result, s.identityObject(v))
+	}
+	return result
+}
+
+func (s *store) rightObject(r *right) object {
+	return object{
+		"id":                r.id,
+		"target":            r.target,
+		"right":             r.right,
+		"rightValuePattern": r.rightValuePattern,
+		"identityID":        r.identityId,
+		"createdAt":         timePtr(r.createdAt),
+		"updatedAt":         timePtr(r.updatedAt),
+		"identity": func() any {
+			return s.identityObject(s.identities[r.identityId])
+		},
+	}
+}
+
+func (s *store) rightObjects(rights []*right) []object {
+	result := make([]object, 0, len(rights))
+	for _, v := range rights {
+		result = append(result, s.rightObject(v))
+	}
+	return result
+}
+
+func (s *store) valueObject(v *value) object {
+	if v == nil {
+		return nil
+	}
+	return object{
+		"id":        v.id,
+		"name":      v.name,
+		"vaultID":   v.vaultId,
+		"type":      v.valueType,
+		"createdAt": timePtr(v.createdAt),
+		"updatedAt": timePtr(v.updatedAt),
+		"vault": func() any {
+			return s.vaultObject(s.vaults[v.vaultId])
+		},
+		"value": func() any {
+			return s.identityValueObjects(s.identityValuesOfValue(v.id))
+		},
+	}
+}
+
+func (s *store) valueObjects(values []*value) []object {
+	result := make([]object, 0, len(values))
+	for _, v := range values {
+		result = append(result, s.valueObject(v))
+	}
+	return result
+}
+
+func (s *store) identityValueObject(iv *identityValue) object {
+	return object{
+		"id":         iv.id,
+		"valueID":    iv.valueId,
+		"identityID": iv.identityId,
+		"passframe":  iv.passframe,
+		"createdAt":  timePtr(iv.createdAt),
+		"updatedAt":  timePtr(iv.updatedAt),
+		"value": func() any {
+			return s.valueObject(s.values[iv.valueId])
+		},
+		"identity": func() any {
+			return s.identityObject(s.identities[iv.identityId])
+		},
+	}
+}
+
+func (s *store) identityValueObjects(identityValues []*identityValue) []object {
+	result := make([]object, 0, len(identityValues))
+	for _, v := range identityValues {
+		result = append(result, s.identityValueObject(v))
+	}
+	return result
+}
+
+func (s *store) identitiesOfVault(vaultId string) []*identity {
+	result := make([]*identity, 0)
+	for _, v := range s.identities {
+		if v.vaultId == vaultId {
+			result = append(result, v)
+		}
+	}
+	sortById(result, func(i *identity) string { return i.id })
+	return result
+}
+
+func (s *store) rightsOfIdentity(identityId string) []*right {
+	result := make([]*right, 0)
+	for _, v := range s.rights {
+		if v.identityId == identityId {
+			result = append(result, v)
+		}
+	}
+	sortById(result, func(r *right) string { return r.id })
+	return result
+}
+
+func (s *store) valuesOfVault(vaultId string) []*value {
+	result := make([]*value, 0)
+	for _, v := range s.values {
+		if v.vaultId == vaultId {
+			result = append(result, v)
+		}
+	}
+	sortById(result, func(v *value) string { return v.id })
+	return result
+}
+
+func (s *store) identityValuesOfValue(valueId string) []*identityValue {
+	result := make([]*identityValue, 0)
+	for _, v := range s.identityValues {
+		if v.valueId == valueId {
+			result = append(result, v)
+		}
+	}
+	sortById(result, func(iv *identityValue) string { return iv.id })
+	return result
+}
+
+func (s *store) identityValuesOfVault(vaultId string) []*identityValue {
+	result := make([]*identityValue, 0)
+	for _, v := range s.identityValues {
+		if val, ok := s.values[v.valueId]; ok && val.vaultId == vaultId {
+			result = append(result, v)
+		}
+	}
+	sortById(result, func(iv *identityValue) string { return iv.id })
+	return result
+}
+
+func (s *store) deleteIdentity(id string) {
+	for k, v := range s.rights {
+		if v.identityId == id {
+			delete(s.rights, k)
+		}
+	}
+	for k, v := range s.identityValues {
+		if v.identityId == id {
+			delete(s.identityValues, k)
+		}
+	}
+	delete(s.identities, id)
+}
+
+func (s *store) deleteValue(id string) {
+	for k, v := range s.identityValues {
+		if v.valueId == id {
+			delete(s.identityValues, k)
+		}
+	}
+	delete(s.values, id)
+}
+
+// hasRight reports whether the identity has a right for target and direction matching resource
+func (s *store) hasRight(identityId, target, direction, resource string) bool {
+	for _, r := range s.rightsOfIdentity(identityId) {
+		if r.target == target && r.right == direction && matchPattern(r.rightValuePattern, resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// coversPattern reports whether the identity has a right for target and direction which
+// includes every subject the given pattern can match
+func (s *store) coversPattern(identityId, target, direction, pattern string) bool {
+	for _, r := range s.rightsOfIdentity(identityId) {
+		if r.target == target && r.right == direction && includesPattern(r.rightValuePattern, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueResource returns the name a value is matched with against VALUES right patterns
+func valueResource(name string) string {
+	if strings.HasPrefix(name, helper.ValuesPrefix) {
+		return name
+	}
+	return helper.ValuesPrefix + name
+}
+
+func identityResource(id string) string {
+	return helper.IdentityPrefix + id
+}
+
+const vaultResource = helper.SystemPrefix + "vault"
+
+// matchPattern matches a subject against a NATS like pattern, * matches exactly one token,
+// > matches one or more tokens at the end.
+func matchPattern(pattern, subject string) bool {
+	p := strings.Split(pattern, ".")
+	t := strings.Split(subject, ".")
+	for i, v := range p {
+		if v == ">" {
+			return len(t) > i
+		}
+		if i >= len(t) {
+			return false
+		}
+		if v != "*" && v != t[i] {
+			return false
+		}
+	}
+	return len(p) == len(t)
+}
+
+// includesPattern reports whether every subject matched by inner is also matched by outer
+func includesPattern(outer, inner string) bool {
+	o := strings.Split(outer, ".")
+	in := strings.Split(inner, ".")
+	for i, v := range o {
+		if v == ">" {
+			return len(in) > i
+		}
+		if i >= len(in) {
+			return false
+		}
+		switch {
+		case in[i] == ">":
+			return false
+		case v == "*":
+		case v != in[i]:
+			return false
+		}
+	}
+	return len(o) == len(in)
+}
+
+func sortById[T any](items []T, id func(T) string) {
+	sort.Slice(items, func(i, j int) bool {
+		return id(items[i]) < id(items[j])
+	})
+}
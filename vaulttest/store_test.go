@@ -0,0 +1,42 @@
+package vaulttest
+
+import "testing"
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		subject string
+		want    bool
+	}{
+		{pattern: "VALUES.a.b", subject: "VALUES.a.b", want: true},
+		{pattern: "VALUES.a.*", subject: "VALUES.a.b", want: true},
+		{pattern: "VALUES.a.*", subject: "VALUES.a.b.c", want: false},
+		{pattern: "VALUES.a.>", subject: "VALUES.a.b.c", want: true},
+		{pattern: "VALUES.a.>", subject: "VALUES.a", want: false},
+		{pattern: "VALUES.a.b", subject: "VALUES.a.c", want: false},
+	}
+	for _, tt := range tests {
+		if got := matchPattern(tt.pattern, tt.subject); got != tt.want {
+			t.Errorf("matchPattern(%s, %s) = %v, want %v", tt.pattern, tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestIncludesPattern(t *testing.T) {
+	tests := []struct {
+		outer string
+		inner string
+		want  bool
+	}{
+		{outer: "VALUES.>", inner: "VALUES.a.>", want: true},
+		{outer: "VALUES.a.*", inner: "VALUES.a.b", want: true},
+		{outer: "VALUES.a.*", inner: "VALUES.a.>", want: false},
+		{outer: "VALUES.a.b", inner: "VALUES.a.*", want: false},
+		{outer: "VALUES.*.c", inner: "VALUES.*.c", want: true},
+	}
+	for _, tt := range tests {
+		if got := includesPattern(tt.outer, tt.inner); got != tt.want {
+			t.Errorf("includesPattern(%s, %s) = %v, want %v", tt.outer, tt.inner, got, tt.want)
+		}
+	}
+}
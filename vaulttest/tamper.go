@@ -0,0 +1,46 @@
+package vaulttest
+
+import (
+	"fmt"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+// The following methods change stored identities without any verification.
+// They simulate manipulated data of a compromised server to test the client side checks.
+
+// SetCreatorVerification overwrites the creator verification of identity id
+func (h *Handler) SetCreatorVerification(id, creatorVerification string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i, ok := h.store.identities[id]
+	if !ok {
+		return fmt.Errorf("identity %s not found", id)
+	}
+	i.creatorVerification = creatorVerification
+	return nil
+}
+
+// SetPublicKey overwrites the public key of identity id
+func (h *Handler) SetPublicKey(id string, publicKey helper.Base64PublicPem) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i, ok := h.store.identities[id]
+	if !ok {
+		return fmt.Errorf("identity %s not found", id)
+	}
+	i.publicKey = publicKey
+	return nil
+}
+
+// SetOperator overwrites the operator flag of identity id
+func (h *Handler) SetOperator(id string, isOperator bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i, ok := h.store.identities[id]
+	if !ok {
+		return fmt.Errorf("identity %s not found", id)
+	}
+	i.isOperator = isOperator
+	return nil
+}
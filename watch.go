@@ -0,0 +1,344 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cryptvault-cloud/helper"
+)
+
+// DefaultWatchInterval is the poll interval of Watch if WithWatchInterval is not set
+const DefaultWatchInterval = 30 * time.Second
+
+// watchPageSize is the number of values and passframes loaded per request while polling
+const watchPageSize = DefaultValuePageSize
+
+// watchFullPollEvery is how often Watch loads all values instead of the changed ones, to notice deletions
+const watchFullPollEvery = 10
+
+// ValueEventType classifies a change reported by Watch
+type ValueEventType string
+
+const (
+	// ValueCreated a value was added and the identity can read it
+	ValueCreated ValueEventType = "created"
+	// ValueUpdated the secret, name or type of a readable value changed, including rotations
+	ValueUpdated ValueEventType = "updated"
+	// ValueDeleted a readable value was deleted or the identity lost its read right for it
+	ValueDeleted ValueEventType = "deleted"
+	// ValueAccessGranted the identity got a passframe for an existing value
+	ValueAccessGranted ValueEventType = "access_granted"
+	// ValueAccessRevoked the passframe of the identity was removed, the value still exists
+	ValueAccessRevoked ValueEventType = "access_revoked"
+	// ValueWatchError polling failed, Err is set and watching continues
+	ValueWatchError ValueEventType = "error"
+)
+
+// ValueEvent is a single change reported by Watch. Events only carry metadata, the secret
+// can be read with GetIdentityValueById.
+type ValueEvent struct {
+	Type      ValueEventType
+	ValueId   string
+	Name      string
+	ValueType ValueType
+	// UpdatedAt is the update time reported by the server, nil if unknown
+	UpdatedAt *time.Time
+	// Err is set for events of type ValueWatchError
+	Err error
+}
+
+// WatchRequest describes a Watch call for a WatchTransport
+type WatchRequest struct {
+	Endpoint string
+	VaultId  string
+	// IdentityId is the id of the watching identity
+	IdentityId string
+	Pattern    string
+	// Signer signs the JWT to authenticate the transport
+	Signer Signer
+}
+
+// WatchTransport delivers value changes to Watch instead of polling, it is only used if set by WithWatchTransport.
+// The returned channel must be closed once ctx is done. Watch falls back to polling if
+// Watch returns an error wrapping ErrWatchUnsupported.
+type WatchTransport interface {
+	Watch(ctx context.Context, req WatchRequest) (<-chan ValueEvent, error)
+}
+
+// Watch reports changes of values matching pattern, f.e.: VALUES.a.>, which are visible to the identity.
+// Without a WatchTransport the server is polled every WithWatchInterval for values whose name starts with the
+// pattern up to its first wildcard, f.e.: VALUES.a. for VALUES.a.>, and which were updated since the previous poll.
+// Deletions and revoked access are only noticed by every tenth poll, which loads all of these values.
+// The first poll only records the current state, its error is returned. The channel is closed once ctx is done.
+// Cached values are invalidated for every update, deletion or revocation seen.
+func (a *ProtectedApi) Watch(ctx context.Context, pattern string) (<-chan ValueEvent, error) {
+	if err := checkValueNamePattern(pattern); err != nil {
+		return nil, err
+	}
+	pemKey, err := helper.NewBase64PublicPem(a.signer.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	identityId, err := pemKey.GetIdentityId(a.vaultId)
+	if err != nil {
+		return nil, err
+	}
+	if a.watchTransport != nil {
+		events, err := a.watchTransport.Watch(ctx, WatchRequest{
+			Endpoint:   a.endpoint,
+			VaultId:    a.vaultId,
+			IdentityId: identityId,
+			Pattern:    pattern,
+			Signer:     a.signer,
+		})
+		if !errors.Is(err, ErrWatchUnsupported) {
+			if err != nil {
+				return nil, err
+			}
+			return a.invalidateOnEvents(ctx, events), nil
+		}
+	}
+
+	w := &valuePoller{api: a, identityId: identityId, pattern: pattern, values: make(map[string]*watchedValue)}
+	if _, err := w.poll(ctx); err != nil {
+		return nil, err
+	}
+	events := make(chan ValueEvent)
+	go w.run(ctx, events)
+	return events, nil
+}
+
+// invalidateOnEvents forwards events of a WatchTransport and invalidates the cached values they change
+func (a *ProtectedApi) invalidateOnEvents(ctx context.Context, events <-chan ValueEvent) <-chan ValueEvent {
+	if a.cache == nil {
+		return events
+	}
+	forwarded := make(chan ValueEvent)
+	go func() {
+		defer close(forwarded)
+		for event := range events {
+			a.invalidateForEvent(event)
+			select {
+			case forwarded <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return forwarded
+}
+
+func (a *ProtectedApi) invalidateForEvent(event ValueEvent) {
+	switch event.Type {
+	case ValueUpdated, ValueDeleted, ValueAccessRevoked:
+		a.InvalidateCachedValue(event.ValueId)
+	}
+}
+
+// watchedValue is the state of a value seen by the last poll
+type watchedValue struct {
+	name      string
+	valueType ValueType
+	updatedAt *time.Time
+	// access is the id of the identity value of the watching identity, empty if it has none
+	access          string
+	accessUpdatedAt *time.Time
+	// created is set if the value was added while watching and was not readable before
+	created bool
+}
+
+// valuePoller implements Watch on top of queryValue and queryIdentityValue. Polls only load the values and
+// own passframes below the literal prefix of the pattern which were updated since the previous poll. Deleted values
+// and passframes can not be queried, so every watchFullPollEvery poll loads all of them to notice deletions.
+type valuePoller struct {
+	api        *ProtectedApi
+	identityId string
+	pattern    string
+	values     map[string]*watchedValue
+	// since and accessSince are the latest update times of values and passframes seen
+	since       time.Time
+	accessSince time.Time
+	polls       int
+}
+
+func (w *valuePoller) run(ctx context.Context, events chan<- ValueEvent) {
+	defer close(events)
+	ticker := time.NewTicker(w.api.watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		changes, err := w.poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			changes = []ValueEvent{{Type: ValueWatchError, Err: err}}
+		}
+		for _, event := range changes {
+			w.api.invalidateForEvent(event)
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// watchedRow is a value loaded by a poll together with the passframe of the watching identity, if it was loaded as well
+type watchedRow struct {
+	id        string
+	name      string
+	valueType ValueType
+	createdAt *time.Time
+	updatedAt *time.Time
+	access    *watchAccessQueryIdentityValueIdentityValueQueryResultDataIdentityValue
+}
+
+// fetch loads the values and passframes of the watching identity below the literal prefix of the pattern,
+// only the ones updated since the previous poll unless full is set
+func (w *valuePoller) fetch(ctx context.Context, full bool) (map[string]*watchedRow, error) {
+	byName := valueNamePrefixFilter(w.pattern)
+	valueFilter := byName
+	accessFilter := &IdentityValueFiltersInput{IdentityID: &StringFilterInput{Eq: &w.identityId}, Value: byName}
+	if !full {
+		// gte, as changes within the same instant as the previous poll would be lost otherwise
+		valueFilter = &ValueFiltersInput{And: []*ValueFiltersInput{byName, {UpdatedAt: &TimeFilterInput{Gte: &w.since}}}}
+		accessFilter.UpdatedAt = &TimeFilterInput{Gte: &w.accessSince}
+	}
+
+	rows := make(map[string]*watchedRow)
+	for offset := 0; ; {
+		resp, err := watchValues(ctx, w.api.client, valueFilter, watchPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		if resp.QueryValue == nil {
+			return nil, fmt.Errorf("watch values of %s: empty response", w.pattern)
+		}
+		for _, v := range resp.QueryValue.Data {
+			rows[v.Id] = &watchedRow{id: v.Id, name: v.Name, valueType: v.Type, createdAt: v.CreatedAt, updatedAt: v.UpdatedAt}
+		}
+		offset += len(resp.QueryValue.Data)
+		if len(resp.QueryValue.Data) < watchPageSize || offset >= resp.QueryValue.TotalCount {
+			break
+		}
+	}
+	for offset := 0; ; {
+		resp, err := watchAccess(ctx, w.api.client, accessFilter, watchPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		if resp.QueryIdentityValue == nil {
+			return nil, fmt.Errorf("watch values of %s: empty response", w.pattern)
+		}
+		for _, v := range resp.QueryIdentityValue.Data {
+			row, ok := rows[v.ValueID]
+			if !ok {
+				if v.Value == nil {
+					continue
+				}
+				// the passframe changed but the value did not
+				row = &watchedRow{id: v.ValueID, name: v.Value.Name, valueType: v.Value.Type, createdAt: v.Value.CreatedAt, updatedAt: v.Value.UpdatedAt}
+				rows[v.ValueID] = row
+			}
+			row.access = v
+		}
+		offset += len(resp.QueryIdentityValue.Data)
+		if len(resp.QueryIdentityValue.Data) < watchPageSize || offset >= resp.QueryIdentityValue.TotalCount {
+			break
+		}
+	}
+	return rows, nil
+}
+
+// poll fetches the changes since the previous poll and returns them as events
+func (w *valuePoller) poll(ctx context.Context) ([]ValueEvent, error) {
+	first := w.since.IsZero()
+	full := first || w.polls%watchFullPollEvery == 0
+	rows, err := w.fetch(ctx, full)
+	if err != nil {
+		return nil, err
+	}
+	w.polls++
+
+	since, accessSince := w.since, w.accessSince
+	events := make([]ValueEvent, 0)
+	current := make(map[string]*watchedValue, len(w.values))
+	if !full {
+		for id, v := range w.values {
+			current[id] = v
+		}
+	}
+	for _, v := range rows {
+		if !matchValueName(w.pattern, v.name) || isCompanionValue(v.name) {
+			continue
+		}
+		next := &watchedValue{name: v.name, valueType: v.valueType, updatedAt: v.updatedAt}
+		prev, known := w.values[v.id]
+		if known {
+			next.created = prev.created && prev.access == ""
+		} else {
+			next.created = !first && v.createdAt != nil && !v.createdAt.Before(w.since)
+		}
+		if v.updatedAt != nil && v.updatedAt.After(since) {
+			since = *v.updatedAt
+		}
+		switch {
+		case v.access != nil:
+			next.access = v.access.Id
+			next.accessUpdatedAt = v.access.UpdatedAt
+			if v.access.UpdatedAt != nil && v.access.UpdatedAt.After(accessSince) {
+				accessSince = *v.access.UpdatedAt
+			}
+		case known && !full:
+			// the passframe did not change since the previous poll
+			next.access, next.accessUpdatedAt = prev.access, prev.accessUpdatedAt
+		}
+		current[v.id] = next
+
+		event := ValueEvent{ValueId: v.id, Name: v.name, ValueType: v.valueType, UpdatedAt: next.updatedAt}
+		switch {
+		case first || next.access == "" && (!known || prev.access == ""):
+			continue
+		case !known || prev.access == "":
+			// a value is added before its passframes, so they may only be seen by the next poll
+			event.Type = ValueAccessGranted
+			if next.created {
+				event.Type = ValueCreated
+			}
+		case next.access == "":
+			event.Type = ValueAccessRevoked
+		case prev.name != next.name || prev.valueType != next.valueType || !equalTime(prev.updatedAt, next.updatedAt) ||
+			prev.access != next.access || !equalTime(prev.accessUpdatedAt, next.accessUpdatedAt):
+			event.Type = ValueUpdated
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	if full && !first {
+		for id, prev := range w.values {
+			if _, ok := current[id]; ok || prev.access == "" {
+				continue
+			}
+			events = append(events, ValueEvent{Type: ValueDeleted, ValueId: id, Name: prev.name, ValueType: prev.valueType})
+		}
+	}
+	if since.IsZero() {
+		// the vault has no values yet, every value added later is newer than now
+		since = time.Unix(0, 0)
+	}
+	if accessSince.IsZero() {
+		accessSince = time.Unix(0, 0)
+	}
+	w.values = current
+	w.since, w.accessSince = since, accessSince
+	return events, nil
+}
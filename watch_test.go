@@ -0,0 +1,253 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cryptvault-cloud/api"
+	"github.com/cryptvault-cloud/api/vaulttest"
+)
+
+// waitForEvent returns the next event of type want. Polls may see intermediate states of an operation,
+// so other events are skipped.
+func waitForEvent(t *testing.T, events <-chan api.ValueEvent, want api.ValueEventType) api.ValueEvent {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("events closed")
+			}
+			if event.Err != nil {
+				t.Fatalf("watch error = %v", event.Err)
+			}
+			if event.Type == want {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("no %s event received", want)
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	a, operator, vaultId := newTestVault(t, api.WithWatchInterval(20*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	identity, err := operator.CreateIdentity("service", rightInputs(t, "(r)VALUES.>"))
+	if err != nil {
+		t.Fatalf("CreateIdentity() error = %v", err)
+	}
+	service := a.GetProtectedApi(identity.PrivateKey, vaultId)
+	if _, err := service.Watch(ctx, "VALUES.a.>.b"); err == nil {
+		t.Error("Watch() with invalid pattern error = nil")
+	}
+	events, err := service.Watch(ctx, "VALUES.a.>")
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	if _, err := operator.AddValue("VALUES.b.x", "other", api.ValueTypeString); err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	valueId, err := operator.AddValue("VALUES.a.x", "secret", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	if event := waitForEvent(t, events, api.ValueCreated); event.ValueId != valueId || event.Name != "VALUES.a.x" {
+		t.Errorf("event = %+v, want created VALUES.a.x", event)
+	}
+
+	if _, err := operator.UpdateValue(valueId, "VALUES.a.x", "updated", api.ValueTypeString); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+	if event := waitForEvent(t, events, api.ValueUpdated); event.ValueId != valueId {
+		t.Errorf("event = %+v, want updated", event)
+	}
+
+	stored, err := operator.GetValueById(valueId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range stored.Value {
+		if v.IdentityID == identity.IdentityId {
+			if _, err := operator.DeleteIdentityValue(&v.Id); err != nil {
+				t.Fatalf("DeleteIdentityValue() error = %v", err)
+			}
+		}
+	}
+	if event := waitForEvent(t, events, api.ValueAccessRevoked); event.ValueId != valueId {
+		t.Errorf("event = %+v, want access revoked", event)
+	}
+
+	if err := operator.SyncValue(valueId); err != nil {
+		t.Fatalf("SyncValue() error = %v", err)
+	}
+	if event := waitForEvent(t, events, api.ValueAccessGranted); event.ValueId != valueId {
+		t.Errorf("event = %+v, want access granted", event)
+	}
+
+	if err := operator.DeleteValue(valueId); err != nil {
+		t.Fatalf("DeleteValue() error = %v", err)
+	}
+	if event := waitForEvent(t, events, api.ValueDeleted); event.ValueId != valueId {
+		t.Errorf("event = %+v, want deleted", event)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+type unsupportedTransport struct {
+	called bool
+}
+
+func (t *unsupportedTransport) Watch(ctx context.Context, req api.WatchRequest) (<-chan api.ValueEvent, error) {
+	t.called = true
+	return nil, api.ErrWatchUnsupported
+}
+
+func TestWatchFallsBackToPolling(t *testing.T) {
+	transport := &unsupportedTransport{}
+	_, operator, _ := newTestVault(t, api.WithWatchInterval(20*time.Millisecond), api.WithWatchTransport(transport))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := operator.Watch(ctx, "VALUES.>")
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if !transport.called {
+		t.Error("transport was not asked")
+	}
+	if _, err := operator.AddValue("VALUES.a", "secret", api.ValueTypeString); err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	if event := waitForEvent(t, events, api.ValueCreated); event.Name != "VALUES.a" {
+		t.Errorf("event = %+v, want created VALUES.a", event)
+	}
+}
+
+func TestWatchPagesValues(t *testing.T) {
+	_, operator, _ := newTestVault(t, api.WithWatchInterval(20*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for i := 0; i <= api.DefaultValuePageSize; i++ {
+		if _, err := operator.AddValue(fmt.Sprintf("VALUES.a.v%d", i), "secret", api.ValueTypeString); err != nil {
+			t.Fatalf("AddValue() error = %v", err)
+		}
+	}
+	events, err := operator.Watch(ctx, "VALUES.a.>")
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	valueId, err := operator.AddValue("VALUES.a.new", "secret", api.ValueTypeString)
+	if err != nil {
+		t.Fatalf("AddValue() error = %v", err)
+	}
+	// values beyond the first page must not look deleted
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Err != nil || event.Type == api.ValueDeleted {
+				t.Fatalf("event = %+v, want no deletes", event)
+			}
+			if event.Type == api.ValueCreated && event.ValueId == valueId {
+				return
+			}
+		case <-timeout:
+			t.Fatal("no created event received")
+		}
+	}
+}
+
+func TestWatchPollsOnlyChangedValues(t *testing.T) {
+	server := vaulttest.NewServer()
+	t.Cleanup(server.Close)
+	a := api.NewApi(server.URL, http.DefaultClient, api.WithWatchInterval(20*time.Millisecond))
+	private, _, vaultId, err := a.NewVault("test", "token")
+	if err != nil {
+		t.Fatalf("NewVault() error = %v", err)
+	}
+	operator := a.GetProtectedApi(private, vaultId)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	valueIds := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		id, err := operator.AddValue(fmt.Sprintf("VALUES.a.v%d", i), "secret", api.ValueTypeString)
+		if err != nil {
+			t.Fatalf("AddValue() error = %v", err)
+		}
+		valueIds = append(valueIds, id)
+	}
+
+	var mu sync.Mutex
+	loaded := make([]int, 0)
+	watcher := a.GetProtectedApiWithHttpClient(private, vaultId, &http.Client{
+		Transport: api.RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			resp, err := http.DefaultTransport.RoundTrip(req)
+			if err != nil || !strings.Contains(string(body), "query watchValues") {
+				return resp, err
+			}
+			data, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = io.NopCloser(bytes.NewReader(data))
+			var result struct {
+				Data struct {
+					QueryValue struct {
+						Data []json.RawMessage `json:"data"`
+					} `json:"queryValue"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(data, &result); err == nil {
+				mu.Lock()
+				loaded = append(loaded, len(result.Data.QueryValue.Data))
+				mu.Unlock()
+			}
+			return resp, nil
+		}),
+	})
+	events, err := watcher.Watch(ctx, "VALUES.a.>")
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if _, err := operator.UpdateValue(valueIds[0], "VALUES.a.v0", "updated", api.ValueTypeString); err != nil {
+		t.Fatalf("UpdateValue() error = %v", err)
+	}
+	if event := waitForEvent(t, events, api.ValueUpdated); event.ValueId != valueIds[0] {
+		t.Errorf("event = %+v, want updated %s", event, valueIds[0])
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(loaded) < 2 || loaded[0] != 3 {
+		t.Fatalf("watchValues loaded %v values, want all 3 by the first poll", loaded)
+	}
+	for _, n := range loaded[1:] {
+		if n < 3 {
+			return
+		}
+	}
+	t.Errorf("watchValues loaded %v values, want only changed values after the first poll", loaded)
+}